@@ -3,11 +3,12 @@ module github.com/alaaelgndy/go-readability
 go 1.13
 
 require (
+	github.com/PuerkitoBio/goquery v1.8.1
 	github.com/go-shiori/dom v0.0.0-20210627111528-4e4722cd0d65
 	github.com/sergi/go-diff v1.1.0
 	github.com/sirupsen/logrus v1.8.1
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/net v0.0.0-20210614182718-04defd469f4e
-	golang.org/x/sys v0.0.0-20210514084401-e8d321eab015 // indirect
+	golang.org/x/net v0.7.0
+	golang.org/x/text v0.7.0
 )
@@ -0,0 +1,77 @@
+package readability
+
+import (
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Limits of the content kept by AboveFoldOnly.
+const (
+	aboveFoldMaxBlocks = 5
+	aboveFoldMaxWords  = 200
+	aboveFoldMinWords  = 50
+)
+
+// aboveFoldMediaTags is the elements which usually take the rest of
+// screen, so they're treated as the fold once there is enough text.
+var aboveFoldMediaTags = []string{"figure", "picture", "img", "video", "iframe", "embed", "object"}
+
+// cutAboveFold removes the content after the heuristic fold boundary,
+// roughly what is visible without scrolling. The content is walked as
+// blocks, i.e. block elements without child block, and the fold is set
+// at the first block which:
+//
+//   - contains media (image, video or embed) and comes after at least
+//     aboveFoldMinWords words, since large media pushes the rest of
+//     content out of the screen. Media before it, e.g. the lead image,
+//     is kept.
+//   - comes after aboveFoldMaxBlocks blocks or aboveFoldMaxWords words.
+//
+// Returns true if any content is removed.
+func (ps *Parser) cutAboveFold(articleContent *html.Node) bool {
+	var boundary *html.Node
+	nBlocks, nWords := 0, 0
+
+	var findBoundary func(*html.Node) bool
+	findBoundary = func(node *html.Node) bool {
+		for child := dom.FirstElementChild(node); child != nil; child = dom.NextElementSibling(child) {
+			tagName := dom.TagName(child)
+			isMedia := indexOf(aboveFoldMediaTags, tagName) != -1
+
+			_, isBlock := blockElems[tagName]
+			if !isMedia && isBlock && ps.hasChildBlockElement(child) {
+				if findBoundary(child) {
+					return true
+				}
+				continue
+			}
+
+			if !isMedia {
+				isMedia = len(ps.getAllNodesWithTag(child, aboveFoldMediaTags...)) > 0
+			}
+
+			if isMedia && nWords >= aboveFoldMinWords ||
+				!isMedia && (nBlocks >= aboveFoldMaxBlocks || nWords >= aboveFoldMaxWords) {
+				boundary = child
+				return true
+			}
+
+			nBlocks++
+			nWords += wordCount(ps.getInnerText(child, true))
+		}
+		return false
+	}
+
+	if !findBoundary(articleContent) {
+		return false
+	}
+
+	// Remove the boundary and everything after it.
+	for node := boundary; node != articleContent; node = node.Parent {
+		for node.NextSibling != nil {
+			node.Parent.RemoveChild(node.NextSibling)
+		}
+	}
+	boundary.Parent.RemoveChild(boundary)
+	return true
+}
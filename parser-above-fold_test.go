@@ -0,0 +1,76 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_cutAboveFold(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		body      string
+		kept      []string
+		removed   []string
+		cutAtFold bool
+	}{
+		{"short article", `<h2>Intro</h2>` + testParagraph + testParagraph,
+			[]string{"Intro"}, nil, false},
+		{"lead image is kept", `<figure><img src="lead.jpg"><figcaption>Lead caption</figcaption></figure>` +
+			testParagraph + `<p>Second paragraph.</p>`,
+			[]string{"lead.jpg", "Lead caption", "Second paragraph"}, nil, false},
+		{"image after text", testParagraph + `<p>Before image.</p><figure><img src="inline.jpg"></figure><p>After image.</p>` + testParagraph,
+			[]string{"Before image"}, []string{"inline.jpg", "After image"}, true},
+		{"too many words", testParagraph + testParagraph + testParagraph + `<p>Far below.</p>`,
+			nil, []string{"Far below"}, true},
+		{"nested blocks", `<section><h2>First</h2><p>Second paragraph.</p></section>` +
+			`<section><h2>Third</h2><p>Fourth paragraph.</p><p>Fifth paragraph.</p><p>Sixth paragraph.</p></section>` + testParagraph,
+			[]string{"First", "Fifth"}, []string{"Sixth", "Lorem"}, true},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><body><article><h1>Title</h1>` + scenario.body + `</article></body></html>`
+		parser := NewParser()
+		parser.AboveFoldOnly = true
+		article := parseHTMLString(t, parser, rawHTML)
+
+		for _, text := range scenario.kept {
+			if !strings.Contains(article.Content, text) {
+				t.Errorf("\n%s: want %q kept in content:\n%s", scenario.name, text, article.Content)
+			}
+		}
+
+		for _, text := range scenario.removed {
+			if strings.Contains(article.Content, text) {
+				t.Errorf("\n%s: want %q removed from content:\n%s", scenario.name, text, article.Content)
+			}
+		}
+
+		if article.CutAtFold != scenario.cutAtFold {
+			t.Errorf("\n%s: want cut at fold %v, got %v", scenario.name, scenario.cutAtFold, article.CutAtFold)
+		}
+
+		if article.Truncated {
+			t.Errorf("\n%s: want cut at fold not reported as truncated", scenario.name)
+		}
+	}
+}
+
+func Test_cutAboveFoldDeclaredWordCount(t *testing.T) {
+	head := `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","wordCount":700}</script>`
+	rawHTML := articleHTML(head, testParagraph+testParagraph+testParagraph)
+
+	parser := NewParser()
+	parser.AboveFoldOnly = true
+	article := parseHTMLString(t, parser, rawHTML)
+	if !article.CutAtFold {
+		t.Fatalf("\nwant content cut at fold:\n%s", article.Content)
+	}
+
+	if article.Truncated || len(article.Warnings) > 0 {
+		t.Errorf("\nwant no truncation for cut at fold, got warnings %q", article.Warnings)
+	}
+
+	if article.DeclaredWordCount != 700 {
+		t.Errorf("\nwant declared word count 700, got %d", article.DeclaredWordCount)
+	}
+}
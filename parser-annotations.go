@@ -0,0 +1,59 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Annotation is an element in the content that annotated by publisher
+// using data attributes, e.g. data-wikidata for entity linking.
+type Annotation struct {
+	// Text is the text content of the annotated element.
+	Text string
+	// Attributes is the annotation attributes and their values.
+	Attributes map[string]string
+	// Offset is the byte offset of Text in the article's TextContent.
+	Offset int
+}
+
+// getAnnotations collects elements in article content which have any
+// of the attributes listed in ExtractDataAnnotations. The offset is
+// counted from the start of article content's text.
+func (ps *Parser) getAnnotations(articleContent *html.Node) []Annotation {
+	if len(ps.ExtractDataAnnotations) == 0 {
+		return nil
+	}
+
+	var annotations []Annotation
+	indexes := make(map[*html.Node]int)
+
+	ps.buildTextContent(articleContent, func(node *html.Node, tb *textBuilder, entering bool) {
+		if !entering {
+			// The text of annotation is complete once its node is left.
+			if idx, exist := indexes[node]; exist {
+				annotations[idx].Text = tb.String()[annotations[idx].Offset:]
+			}
+			return
+		}
+
+		attributes := make(map[string]string)
+		for _, attrName := range ps.ExtractDataAnnotations {
+			attrName = strings.ToLower(strings.TrimSpace(attrName))
+			if dom.HasAttribute(node, attrName) {
+				attributes[attrName] = dom.GetAttribute(node, attrName)
+			}
+		}
+
+		if len(attributes) > 0 {
+			indexes[node] = len(annotations)
+			annotations = append(annotations, Annotation{
+				Attributes: attributes,
+				Offset:     tb.Len(),
+			})
+		}
+	})
+
+	return annotations
+}
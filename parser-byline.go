@@ -0,0 +1,57 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	rxBylinePrefix    = regexp.MustCompile(`(?i)^(?:(?:written|posted|words|reported|story|text)\s+by|by|authors?)(?:\s*[:：\-–—]\s*|\s+)`)
+	rxBylineSeparator = regexp.MustCompile(`\s*[|•·]\s*`)
+	rxBylineNoise     = regexp.MustCompile(`(?i)^(?:updated|published|posted|last modified)\b|\bago$|^\d+\s*(?:min(?:ute)?s?|hours?)\s*(?:read|listen)?$`)
+	rxBylineRole      = regexp.MustCompile(`(?i)\b(?:editor|writer|correspondent|columnist|staff|reporter|contributor|contributing|producer|photographer|analyst|bureau|chief|desk)s?\b`)
+	rxBylinePlace     = regexp.MustCompile(`^(?:New|Los|Las|San|Santa|St\.?|Saint|North|South|East|West|Hong|Abu|Tel|Rio|Buenos|Kuala|Cape|Mexico)\s|,\s*[A-Z]{2}$|\bD\.?C\.?$|\b(?:City|County|Bay|Beach|Island|Valley)$`)
+)
+
+// cleanByline normalizes the byline found in metadata or content, by
+// removing the common prefixes (e.g. "By" and "Written by") and the
+// fragments separated by "|" or "•" which aren't name, e.g. the date or
+// section of article. The names left are joined with comma. Returns the
+// byline as it is if nothing is left after cleaning.
+func cleanByline(byline string) string {
+	if names := bylineNames(byline); len(names) > 0 {
+		return strings.Join(names, ", ")
+	}
+	return strings.Join(strings.Fields(byline), " ")
+}
+
+// bylineNames splits byline by "|", "•" or "·", and returns the fragments
+// which look like name. The fragments that dropped are the date, the
+// reading time, the time of update (e.g. "Updated 2 hours ago") and the
+// fragments after a name which are a single word, role or place, since
+// they usually are the section, job title or dateline of the author (e.g.
+// "By John Doe | Politics" or "Jane Doe | Senior Editor").
+func bylineNames(byline string) []string {
+	byline = strings.Join(strings.Fields(byline), " ")
+
+	var names []string
+	for _, fragment := range rxBylineSeparator.Split(byline, -1) {
+		fragment = strings.TrimSpace(rxBylinePrefix.ReplaceAllString(fragment, ""))
+		if fragment == "" || rxBylineNoise.MatchString(fragment) {
+			continue
+		}
+
+		if _, isDate := ParseDate(fragment); isDate {
+			continue
+		}
+
+		if len(names) > 0 && (!strings.Contains(fragment, " ") ||
+			rxBylineRole.MatchString(fragment) || rxBylinePlace.MatchString(fragment)) {
+			continue
+		}
+
+		names = append(names, fragment)
+	}
+
+	return names
+}
@@ -0,0 +1,78 @@
+package readability
+
+import (
+	"testing"
+)
+
+func Test_cleanByline(t *testing.T) {
+	scenarios := map[string]string{
+		"John Doe":                               "John Doe",
+		"By John Doe":                            "John Doe",
+		"by  John\n Doe":                         "John Doe",
+		"BY JOHN DOE":                            "JOHN DOE",
+		"By: John Doe":                           "John Doe",
+		"Written by Jane Smith":                  "Jane Smith",
+		"Posted by Jane Smith":                   "Jane Smith",
+		"Author: Jane Smith":                     "Jane Smith",
+		"Authors: Jane Smith and John Doe":       "Jane Smith and John Doe",
+		"By John Doe | May 1, 2023":              "John Doe",
+		"By John Doe | Politics":                 "John Doe",
+		"Jane Smith • 2023-05-01 • 5 min read":   "Jane Smith",
+		"2023-05-01 | By Jane Smith":             "Jane Smith",
+		"Byron Katie":                            "Byron Katie",
+		"Bylines Weekly":                         "Bylines Weekly",
+		"Authority Staff":                        "Authority Staff",
+		"By":                                     "By",
+		"Jane Smith, Staff Writer":               "Jane Smith, Staff Writer",
+		"By Reuters Staff · Updated 2 hours ago": "Reuters Staff",
+		"Jane Doe | John Smith":                  "Jane Doe, John Smith",
+		"By Jane Doe • John Smith • May 1, 2023": "Jane Doe, John Smith",
+		"Jane Doe | Published Jan 2, 2020":       "Jane Doe",
+		"Jane Doe | Senior Editor":               "Jane Doe",
+		"John Doe | New York":                    "John Doe",
+		"John Doe | Staff Writer":                "John Doe",
+		"Jane Doe | Austin, TX":                  "Jane Doe",
+	}
+
+	for byline, expected := range scenarios {
+		if got := cleanByline(byline); got != expected {
+			t.Errorf("\n%q: want %q, got %q", byline, expected, got)
+		}
+	}
+}
+
+func Test_rawByline(t *testing.T) {
+	rawHTML := `<html><body><article><p class="byline">By John Doe | May 1, 2023</p>` +
+		testParagraph + testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if article.Byline != "John Doe" {
+		t.Errorf("\nwant byline %q, got %q", "John Doe", article.Byline)
+	}
+
+	if article.RawByline != "By John Doe | May 1, 2023" {
+		t.Errorf("\nwant raw byline %q, got %q", "By John Doe | May 1, 2023", article.RawByline)
+	}
+}
+
+func Test_bylineAuthors(t *testing.T) {
+	rawHTML := `<html><body><article><p class="byline">By Jane Doe | John Smith | Politics</p>` +
+		testParagraph + testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if article.Byline != "Jane Doe, John Smith" {
+		t.Errorf("\nwant byline %q, got %q", "Jane Doe, John Smith", article.Byline)
+	}
+
+	if len(article.Authors) != 2 || article.Authors[0] != "Jane Doe" || article.Authors[1] != "John Smith" {
+		t.Errorf("\nwant both authors, got %q", article.Authors)
+	}
+	for _, byline := range []string{"Jane Doe | Senior Editor", "Jane Doe | New York"} {
+		rawHTML = `<html><body><article><p class="byline">By ` + byline + `</p>` +
+			testParagraph + testParagraph + `</article></body></html>`
+		article = parseHTMLString(t, NewParser(), rawHTML)
+		if len(article.Authors) != 1 || article.Authors[0] != "Jane Doe" {
+			t.Errorf("\n%q: want only the author, got %q", byline, article.Authors)
+		}
+	}
+}
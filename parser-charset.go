@@ -0,0 +1,131 @@
+package readability
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+)
+
+// charsetPrescanLength is the number of bytes at the start of document
+// which searched for the charset declaration, the same as browsers do.
+const charsetPrescanLength = 1024
+
+// charsetSampleLength is the number of bytes which checked for valid
+// UTF-8 when the input is decoded without reading all of it.
+const charsetSampleLength = 8192
+
+// decodeCharset reads the whole input and converts it to UTF-8, using
+// the charset detected by detectCharset.
+func decodeCharset(input io.Reader) (io.Reader, error) {
+	content, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader = bytes.NewReader(content)
+	if e := detectCharset(content); e != nil {
+		reader = transform.NewReader(reader, e.NewDecoder())
+	}
+
+	return reader, nil
+}
+
+// decodeCharsetStream is like decodeCharset, but only the start of input
+// is used to detect the charset, so the rest of input can be streamed.
+func decodeCharsetStream(input io.Reader) io.Reader {
+	bufInput := bufio.NewReaderSize(input, charsetSampleLength)
+	sample, _ := bufInput.Peek(charsetSampleLength)
+
+	// Eliminate the partial rune at the end of sample.
+	for i := len(sample) - 1; i >= 0 && i > len(sample)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(sample[i]) {
+			if !utf8.FullRune(sample[i:]) {
+				sample = sample[:i]
+			}
+			break
+		}
+	}
+
+	if e := detectCharset(sample); e != nil {
+		return transform.NewReader(bufInput, e.NewDecoder())
+	}
+	return bufInput
+}
+
+// detectCharset returns the encoding of content which needs to be
+// converted to UTF-8, or nil if it's already UTF-8 or its encoding is
+// unknown. Byte order mark is always trusted. Charset declared in the
+// <meta> is only used if content is not valid UTF-8, since many pages
+// are converted to UTF-8 (e.g. by crawler) without updating the
+// declaration.
+func detectCharset(content []byte) encoding.Encoding {
+	prescan := content
+	if len(prescan) > charsetPrescanLength {
+		prescan = prescan[:charsetPrescanLength]
+	}
+
+	if e, _, certain := charset.DetermineEncoding(prescan, ""); certain {
+		if e == encoding.Nop {
+			return nil
+		}
+		return e
+	}
+
+	if utf8.Valid(content) {
+		return nil
+	}
+
+	label := declaredCharset(prescan)
+	if label == "" {
+		return nil
+	}
+
+	e, _ := charset.Lookup(label)
+	if e == encoding.Nop {
+		return nil
+	}
+	return e
+}
+
+// declaredCharset returns the charset label declared in the <meta>
+// element, either in its charset attribute or in the content of
+// http-equiv="Content-Type".
+func declaredCharset(prescan []byte) string {
+	tokenizer := html.NewTokenizer(bytes.NewReader(prescan))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			return ""
+		}
+
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+
+		token := tokenizer.Token()
+		if token.DataAtom != atom.Meta {
+			continue
+		}
+
+		if label := strings.TrimSpace(tokenAttribute(token, "charset")); label != "" {
+			return label
+		}
+
+		if strings.EqualFold(tokenAttribute(token, "http-equiv"), "content-type") {
+			_, params, err := mime.ParseMediaType(tokenAttribute(token, "content"))
+			if err == nil && params["charset"] != "" {
+				return params["charset"]
+			}
+		}
+	}
+}
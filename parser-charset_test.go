@@ -0,0 +1,95 @@
+package readability
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/url"
+	fp "path/filepath"
+	"testing"
+	"unicode/utf16"
+)
+
+func Test_detectCharset(t *testing.T) {
+	source, err := ioutil.ReadFile(fp.Join("test-pages", "windows-1251", "source.html"))
+	if err != nil {
+		t.Fatalf("\nfailed to read test file: %v", err)
+	}
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	expectedTitle := "Зимние прогулки по Санкт-Петербургу"
+
+	parser := NewParser()
+	article, err := parser.Parse(bytes.NewReader(source), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse test file: %v", err)
+	}
+
+	if article.Title != expectedTitle {
+		t.Errorf("\nParse: want title %q, got %q", expectedTitle, article.Title)
+	}
+
+	if expectedByline := "Анна Петрова"; article.Byline != expectedByline {
+		t.Errorf("\nParse: want byline %q, got %q", expectedByline, article.Byline)
+	}
+
+	article, err = parser.ParseMetadataFast(bytes.NewReader(source), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse metadata of test file: %v", err)
+	}
+
+	if article.Title != expectedTitle {
+		t.Errorf("\nParseMetadataFast: want title %q, got %q", expectedTitle, article.Title)
+	}
+
+	// The tokenizer of ParseMetadataFast doesn't guess the charset on
+	// its own, unlike the full parse.
+	parser.DetectCharset = false
+	article, _ = parser.ParseMetadataFast(bytes.NewReader(source), pageURL)
+	if article.Title == expectedTitle {
+		t.Errorf("\nwant title left undecoded when DetectCharset is false")
+	}
+}
+
+func Test_detectCharsetScenarios(t *testing.T) {
+	rawHTML := func(head, title string) string {
+		return `<html><head>` + head + `<title>` + title + `</title></head>` +
+			`<body><article>` + testParagraph + testParagraph + `</article></body></html>`
+	}
+
+	utf16BE := []byte{0xFE, 0xFF}
+	for _, r := range utf16.Encode([]rune(rawHTML("", "Grüße"))) {
+		utf16BE = append(utf16BE, byte(r>>8), byte(r))
+	}
+
+	scenarios := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{{
+		name:     "meta charset",
+		input:    []byte(rawHTML(`<meta charset="iso-8859-1">`, "Gr\xfc\xdfe")),
+		expected: "Grüße",
+	}, {
+		name:     "UTF-8 with stale declaration",
+		input:    []byte(rawHTML(`<meta charset="gbk">`, "Grüße")),
+		expected: "Grüße",
+	}, {
+		name:     "UTF-16 byte order mark",
+		input:    utf16BE,
+		expected: "Grüße",
+	}}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+		article, err := parser.Parse(bytes.NewReader(scenario.input), pageURL)
+		if err != nil {
+			t.Errorf("\n%s: failed to parse: %v", scenario.name, err)
+			continue
+		}
+
+		if article.Title != scenario.expected {
+			t.Errorf("\n%s: want title %q, got %q", scenario.name, scenario.expected, article.Title)
+		}
+	}
+}
@@ -9,6 +9,13 @@ import (
 	"golang.org/x/net/html"
 )
 
+// Default thresholds of Check, the same as isProbablyReaderable of
+// Readability.js.
+const (
+	defaultCheckMinContentLength = 140
+	defaultCheckMinScore         = 20
+)
+
 // Check checks whether the input is readable without parsing the whole thing.
 func (ps *Parser) Check(input io.Reader) bool {
 	// Parse input
@@ -62,6 +69,16 @@ func (ps *Parser) CheckDocument(doc *html.Node) bool {
 
 	finder(doc)
 
+	minContentLength := ps.CheckMinContentLength
+	if minContentLength <= 0 {
+		minContentLength = defaultCheckMinContentLength
+	}
+
+	minScore := ps.CheckMinScore
+	if minScore <= 0 {
+		minScore = defaultCheckMinScore
+	}
+
 	// This is a little cheeky, we use the accumulator 'score' to decide what
 	// to return from this callback.
 	score := float64(0)
@@ -82,12 +99,12 @@ func (ps *Parser) CheckDocument(doc *html.Node) bool {
 
 		nodeText := strings.TrimSpace(dom.TextContent(node))
 		nodeTextLength := charCount(nodeText)
-		if nodeTextLength < 140 {
+		if nodeTextLength < minContentLength {
 			return false
 		}
 
-		score += math.Sqrt(float64(nodeTextLength - 140))
-		if score > 20 {
+		score += math.Sqrt(float64(nodeTextLength - minContentLength))
+		if score > minScore {
 			return true
 		}
 
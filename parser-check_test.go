@@ -0,0 +1,39 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_Check(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat(testSentence, 6) + "</p>"
+	article := `<html><body><nav><a href="/">Home</a></nav><article>` +
+		strings.Repeat(paragraph, 4) + `</article></body></html>`
+	navigation := `<html><body><nav><ul>` +
+		strings.Repeat(`<li><a href="/section">Section</a></li>`, 30) +
+		`</ul></nav><p>Welcome to our site.</p><footer><p>Copyright 2023</p></footer></body></html>`
+
+	scenarios := []struct {
+		name             string
+		rawHTML          string
+		minContentLength int
+		minScore         float64
+		readable         bool
+	}{
+		{"article", article, 0, 0, true},
+		{"navigation only", navigation, 0, 0, false},
+		{"article below raised score", article, 0, 100, false},
+		{"article below raised length", article, 400, 0, false},
+		{"navigation with lowered thresholds", navigation, 10, 1, true},
+	}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.CheckMinContentLength = scenario.minContentLength
+		parser.CheckMinScore = scenario.minScore
+
+		if readable := parser.Check(strings.NewReader(scenario.rawHTML)); readable != scenario.readable {
+			t.Errorf("\n%s: want readable %v, got %v", scenario.name, scenario.readable, readable)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package readability
+
+// ConditionalCleanThresholds is the cutoffs used while conditionally
+// cleaning the content, i.e. removing the forms, tables, lists and divs
+// which look like boilerplate. Each field which left zero uses its
+// default value, so only the ones that need tuning have to be set. To
+// use zero as the threshold, set the field to any negative value, e.g.
+// MinContentLength: -1 keeps the nodes of any length.
+type ConditionalCleanThresholds struct {
+	// MinCommas is the number of commas which makes the node considered
+	// as prose, so it's always kept. Raise it to check more nodes.
+	// Default: 10.
+	MinCommas int
+	// MinParagraphsPerImage is the min ratio of paragraphs to images in
+	// node which has more than one image. Lower it, or set it negative
+	// to disable the check, to keep galleries. Default: 0.5.
+	MinParagraphsPerImage float64
+	// ListItemAllowance is the number of list items which allowed beyond
+	// the number of paragraphs in node which isn't a list. Default: 100.
+	ListItemAllowance int
+	// MinContentLength is the min number of chars of node which has no
+	// image or more than MaxImages images. Lower it to keep short
+	// paragraphs. Default: 25.
+	MinContentLength int
+	// MaxImages is the number of images above which short node (see
+	// MinContentLength) is removed. Default: 2.
+	MaxImages int
+	// MaxLinkDensity is the max link density of node whose class weight
+	// is lower than HighClassWeight. Raise it to keep link-heavy
+	// paragraphs. Default: 0.2.
+	MaxLinkDensity float64
+	// HighClassWeight is the class weight at which the node is trusted
+	// more, so MaxLinkDensityHighWeight is used instead of MaxLinkDensity.
+	// Default: 25.
+	HighClassWeight int
+	// MaxLinkDensityHighWeight is the max link density of node whose
+	// class weight is at least HighClassWeight. Default: 0.5.
+	MaxLinkDensityHighWeight float64
+	// MinEmbedContentLength is the min number of chars of node which has
+	// a single non-video embed. Default: 75.
+	MinEmbedContentLength int
+	// MaxEmbeds is the max number of non-video embeds in node.
+	// Default: 1.
+	MaxEmbeds int
+}
+
+// defaultConditionalCleanThresholds is the thresholds used by
+// Readability.js.
+var defaultConditionalCleanThresholds = ConditionalCleanThresholds{
+	MinCommas:                10,
+	MinParagraphsPerImage:    0.5,
+	ListItemAllowance:        100,
+	MinContentLength:         25,
+	MaxImages:                2,
+	MaxLinkDensity:           0.2,
+	HighClassWeight:          25,
+	MaxLinkDensityHighWeight: 0.5,
+	MinEmbedContentLength:    75,
+	MaxEmbeds:                1,
+}
+
+// getConditionalCleanThresholds returns ConditionalCleanThresholds of
+// the parser, with default values for the fields which left zero and
+// zero for the negative ones.
+func (ps *Parser) getConditionalCleanThresholds() ConditionalCleanThresholds {
+	thresholds := ps.ConditionalCleanThresholds
+	defaults := defaultConditionalCleanThresholds
+
+	thresholds.MinCommas = cleanThresholdInt(thresholds.MinCommas, defaults.MinCommas)
+	thresholds.MinParagraphsPerImage = cleanThresholdFloat(thresholds.MinParagraphsPerImage, defaults.MinParagraphsPerImage)
+	thresholds.ListItemAllowance = cleanThresholdInt(thresholds.ListItemAllowance, defaults.ListItemAllowance)
+	thresholds.MinContentLength = cleanThresholdInt(thresholds.MinContentLength, defaults.MinContentLength)
+	thresholds.MaxImages = cleanThresholdInt(thresholds.MaxImages, defaults.MaxImages)
+	thresholds.MaxLinkDensity = cleanThresholdFloat(thresholds.MaxLinkDensity, defaults.MaxLinkDensity)
+	thresholds.HighClassWeight = cleanThresholdInt(thresholds.HighClassWeight, defaults.HighClassWeight)
+	thresholds.MaxLinkDensityHighWeight = cleanThresholdFloat(thresholds.MaxLinkDensityHighWeight, defaults.MaxLinkDensityHighWeight)
+	thresholds.MinEmbedContentLength = cleanThresholdInt(thresholds.MinEmbedContentLength, defaults.MinEmbedContentLength)
+	thresholds.MaxEmbeds = cleanThresholdInt(thresholds.MaxEmbeds, defaults.MaxEmbeds)
+
+	return thresholds
+}
+
+// cleanThresholdInt returns defaultValue if value is zero, or zero if
+// value is negative.
+func cleanThresholdInt(value, defaultValue int) int {
+	switch {
+	case value == 0:
+		return defaultValue
+	case value < 0:
+		return 0
+	}
+	return value
+}
+
+// cleanThresholdFloat is like cleanThresholdInt, for float threshold.
+func cleanThresholdFloat(value, defaultValue float64) float64 {
+	switch {
+	case value == 0:
+		return defaultValue
+	case value < 0:
+		return 0
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_conditionalCleanThresholds(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<div><p>Read more in <a href="/a">the first report</a> and <a href="/b">the second report</a> here.</p></div>` +
+		testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if strings.Contains(article.TextContent, "the first report") {
+		t.Errorf("\nwant link-dense div removed by default, got %q", article.TextContent)
+	}
+
+	parser := NewParser()
+	parser.ConditionalCleanThresholds.MaxLinkDensity = 0.9
+	article = parseHTMLString(t, parser, rawHTML)
+	if !strings.Contains(article.TextContent, "the first report") {
+		t.Errorf("\nwant link-dense div kept with looser threshold, got %q", article.TextContent)
+	}
+	// Negative value disables the check, so galleries are kept.
+	galleryHTML := `<html><body><article>` + testParagraph +
+		`<div><p><img src="1.jpg"><img src="2.jpg"><img src="3.jpg"><img src="4.jpg"><img src="5.jpg"></p>` +
+		`<p>Photos from the final match of the season.</p></div>` +
+		testParagraph + `</article></body></html>`
+
+	article = parseHTMLString(t, NewParser(), galleryHTML)
+	if strings.Contains(article.Content, "2.jpg") {
+		t.Errorf("\nwant gallery removed by default, got %s", article.Content)
+	}
+
+	parser = NewParser()
+	parser.ConditionalCleanThresholds.MinParagraphsPerImage = -1
+	article = parseHTMLString(t, parser, galleryHTML)
+	if !strings.Contains(article.Content, "2.jpg") {
+		t.Errorf("\nwant gallery kept when check is disabled, got %s", article.Content)
+	}
+}
+
+func Test_getConditionalCleanThresholds(t *testing.T) {
+	parser := NewParser()
+	parser.ConditionalCleanThresholds.MinContentLength = 10
+	parser.ConditionalCleanThresholds.MinParagraphsPerImage = -1
+
+	thresholds := parser.getConditionalCleanThresholds()
+	expected := defaultConditionalCleanThresholds
+	expected.MinContentLength = 10
+	expected.MinParagraphsPerImage = 0
+	if thresholds != expected {
+		t.Errorf("\n"+
+			"want : %+v\n"+
+			"got  : %+v", expected, thresholds)
+	}
+}
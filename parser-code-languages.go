@@ -0,0 +1,121 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Prefix of the class that marks the language of code block.
+const codeLanguageClassPrefix = "language-"
+
+// codeLanguageAliases maps the common aliases of languages into their
+// normalized names.
+var codeLanguageAliases = map[string]string{
+	"js":      "javascript",
+	"node":    "javascript",
+	"ts":      "typescript",
+	"py":      "python",
+	"py3":     "python",
+	"python3": "python",
+	"rb":      "ruby",
+	"golang":  "go",
+	"sh":      "bash",
+	"shell":   "bash",
+	"zsh":     "bash",
+	"console": "bash",
+	"yml":     "yaml",
+	"c++":     "cpp",
+	"cs":      "csharp",
+	"c#":      "csharp",
+	"kt":      "kotlin",
+	"rs":      "rust",
+	"md":      "markdown",
+	"objc":    "objective-c",
+	"ps1":     "powershell",
+}
+
+// normalizeCodeLanguages detects the declared language of every <pre>
+// and <code> in article content, then replaces the language classes
+// with a single normalized class="language-X".
+func (ps *Parser) normalizeCodeLanguages(articleContent *html.Node) {
+	for _, node := range ps.getAllNodesWithTag(articleContent, "pre", "code") {
+		language := ps.getCodeLanguage(node)
+		if language == "" {
+			continue
+		}
+
+		var classes []string
+		for _, class := range strings.Fields(dom.ClassName(node)) {
+			if !rxCodeLanguageClass.MatchString(class) {
+				classes = append(classes, class)
+			}
+		}
+
+		classes = append(classes, codeLanguageClassPrefix+language)
+		dom.SetAttribute(node, "class", strings.Join(classes, " "))
+	}
+}
+
+// getCodeLanguage returns the normalized language declared in the
+// data-lang or data-language attribute of node, or in its class. For
+// <pre>, the language might be declared in its wrapper as well, e.g.
+// <div class="highlight highlight-source-js"> used by GitHub.
+func (ps *Parser) getCodeLanguage(node *html.Node) string {
+	language := ps.getDeclaredCodeLanguage(node)
+	if language == "" && dom.TagName(node) == "pre" && node.Parent != nil &&
+		dom.TagName(node.Parent) == "div" && len(dom.Children(node.Parent)) == 1 {
+		language = ps.getDeclaredCodeLanguage(node.Parent)
+	}
+
+	language = strings.ToLower(strings.TrimSpace(language))
+	switch language {
+	case "", "none", "nohighlight", "plain":
+		return ""
+	}
+
+	if alias, exist := codeLanguageAliases[language]; exist {
+		return alias
+	}
+	return language
+}
+
+// getDeclaredCodeLanguage returns the raw language declared in the
+// attributes of node.
+func (ps *Parser) getDeclaredCodeLanguage(node *html.Node) string {
+	if language := strOr(dom.GetAttribute(node, "data-lang"), dom.GetAttribute(node, "data-language")); language != "" {
+		return language
+	}
+
+	for _, class := range strings.Fields(dom.ClassName(node)) {
+		if parts := rxCodeLanguageClass.FindStringSubmatch(class); parts != nil {
+			return parts[1]
+		}
+	}
+
+	return ""
+}
+
+// isCodeInPre determines if node is <code> inside <pre>, whose class is
+// kept as it is for the syntax highlighter downstream.
+func (ps *Parser) isCodeInPre(node *html.Node) bool {
+	return dom.TagName(node) == "code" && ps.hasAncestorTag(node, "pre", -1, nil)
+}
+
+// isCodeBlockWrapper determines if node is mostly made of <pre>, e.g.
+// the <div> that wraps code block along with its short caption.
+func (ps *Parser) isCodeBlockWrapper(node *html.Node) bool {
+	pres := dom.GetElementsByTagName(node, "pre")
+	if len(pres) == 0 {
+		return false
+	}
+
+	var preLength int
+	for _, pre := range pres {
+		preLength += charCount(ps.getInnerText(pre, true))
+	}
+
+	nodeLength := charCount(ps.getInnerText(node, true))
+	return nodeLength > 0 && float64(preLength)/float64(nodeLength) > 0.9
+}
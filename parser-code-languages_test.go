@@ -0,0 +1,40 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_normalizeCodeLanguages(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<pre class="highlight"><code class="language-golang">package main</code></pre>` +
+		`<pre class="prettyprint lang-py">import os</pre>` +
+		`<div class="highlight highlight-source-js"><pre>let total = items.reduce((sum, item) => sum + item.price, 0)</pre></div>` +
+		`<pre data-lang="Ruby">puts 1</pre>` +
+		`<pre class="nohighlight">plain text</pre>` +
+		testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	for _, fragment := range []string{`<pre><code class="language-golang">`, `<pre>import os</pre>`} {
+		if !strings.Contains(article.Content, fragment) {
+			t.Errorf("\nwant languages to be kept as it is by default, want %s, got %s", fragment, article.Content)
+		}
+	}
+
+	parser.ExtractCodeLanguages = true
+	article = parseHTMLString(t, parser, rawHTML)
+	expected := []string{
+		`<code class="language-go">package main</code>`,
+		`<pre class="language-python">import os</pre>`,
+		`<pre class="language-javascript">let total = items.reduce((sum, item) =&gt; sum + item.price, 0)</pre>`,
+		`<pre data-lang="Ruby" class="language-ruby">puts 1</pre>`,
+		`<pre>plain text</pre>`,
+	}
+
+	for _, fragment := range expected {
+		if !strings.Contains(article.Content, fragment) {
+			t.Errorf("\nwant %s, got %s", fragment, article.Content)
+		}
+	}
+}
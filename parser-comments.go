@@ -0,0 +1,68 @@
+package readability
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// getCommentCount returns the number of comments on the article and
+// where it's taken from. The commentCount in JSON-LD is preferred, then
+// if ExtractCommentCount is enabled, the count is parsed from the heading
+// of comments section like "142 Comments" or "Comments (1,234)". Since
+// the comments section is removed while looking for content, this must
+// be run before grabArticle.
+func (ps *Parser) getCommentCount(jsonLd map[string]string) (int, string) {
+	if count, err := strconv.Atoi(jsonLd["commentCount"]); err == nil && count > 0 {
+		return count, "json-ld"
+	}
+
+	if !ps.ExtractCommentCount {
+		return 0, ""
+	}
+
+	var count int
+	containers := dom.QuerySelectorAll(ps.doc, "div, section")
+	ps.someNode(containers, func(container *html.Node) bool {
+		if !rxCommentsSection.MatchString(dom.ClassName(container) + " " + dom.ID(container)) {
+			return false
+		}
+
+		headings := dom.QuerySelectorAll(container, "h1, h2, h3, h4, h5, h6")
+		return ps.someNode(headings, func(heading *html.Node) bool {
+			text := ps.getInnerText(heading, true)
+			if charCount(text) > 40 {
+				return false
+			}
+
+			parts := rxCommentCount.FindStringSubmatch(text)
+			if parts == nil {
+				return false
+			}
+
+			count = parseGroupedNumber(strOr(parts[1], parts[2]))
+			return count > 0
+		})
+	})
+
+	if count == 0 {
+		return 0, ""
+	}
+	return count, "text"
+}
+
+// parseGroupedNumber parses integer whose thousands might be grouped
+// with comma, dot or space (e.g. "1,234" or "1.234").
+func parseGroupedNumber(str string) int {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, str)
+
+	number, _ := strconv.Atoi(digits)
+	return number
+}
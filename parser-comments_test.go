@@ -0,0 +1,63 @@
+package readability
+
+import (
+	"testing"
+)
+
+func Test_parseGroupedNumber(t *testing.T) {
+	scenarios := map[string]int{
+		"142":       142,
+		"1,234":     1234,
+		"1.234":     1234,
+		"1 234 567": 1234567,
+		"":          0,
+	}
+
+	for str, expected := range scenarios {
+		if result := parseGroupedNumber(str); result != expected {
+			t.Errorf("\n%q: want %d, got %d", str, expected, result)
+		}
+	}
+}
+
+func Test_getCommentCount(t *testing.T) {
+	type result struct {
+		count  int
+		source string
+	}
+
+	scenarios := map[string]result{
+		`<div id="comments"><h3>142 Comments</h3><p>Nice post!</p></div>`:        {142, "text"},
+		`<div class="comments"><h3>Comments (1.234)</h3><p>Nice post!</p></div>`: {1234, "text"},
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","commentCount":17}</script>` +
+			`<div id="comments"><h3>142 Comments</h3></div>`: {17, "json-ld"},
+		`<div id="comments"><h3>Leave a comment</h3></div>`: {},
+		`<div class="sidebar"><p><a href="/other">Other story</a> <span>87 comments</span></p></div>` +
+			`<section id="comments"><h3>12 Comments</h3><p>Nice post!</p></section>`: {12, "text"},
+		`<div class="sidebar"><h4>87 comments</h4></div>`: {},
+	}
+
+	for extraHTML, expected := range scenarios {
+		rawHTML := `<html><body><article>` + testParagraph + testParagraph + `</article>` + extraHTML + `</body></html>`
+
+		parser := NewParser()
+		parser.ExtractCommentCount = true
+		article := parseHTMLString(t, parser, rawHTML)
+
+		if article.CommentCount != expected.count || article.CommentCountSource != expected.source {
+			t.Errorf("\n"+
+				"html : %s\n"+
+				"want : %d (%s)\n"+
+				"got  : %d (%s)", extraHTML,
+				expected.count, expected.source,
+				article.CommentCount, article.CommentCountSource)
+		}
+	}
+
+	// Visible text is only parsed when enabled.
+	rawHTML := `<html><body><article>` + testParagraph + testParagraph + `</article>` +
+		`<div id="comments"><h3>142 Comments</h3></div></body></html>`
+	if article := parseHTMLString(t, NewParser(), rawHTML); article.CommentCount != 0 {
+		t.Errorf("\nwant no comment count by default, got %d", article.CommentCount)
+	}
+}
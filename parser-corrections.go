@@ -0,0 +1,82 @@
+package readability
+
+import (
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// maxCorrectionLength is the max number of chars of a correction block,
+// to avoid mistaking a whole section as correction.
+const maxCorrectionLength = 1000
+
+// extractCorrections looks for the corrections and editor's notes in the
+// article, i.e. element whose class or id says it's correction, or
+// paragraph which starts with "Correction:", "Editor's note:", "An
+// earlier version of this article..." and so on. Only <article> and
+// <main> (or <body> if there are none) are searched, and the footer
+// outside of article is skipped, so the site-wide blocks like corrections
+// policy are never taken. Returns their text in document order. If
+// StripCorrections is enabled, they're removed from the document so they
+// don't end up in the content.
+func (ps *Parser) extractCorrections() []string {
+	var nodes []*html.Node
+	for _, container := range ps.getCorrectionContainers() {
+		nodes = append(nodes, dom.GetElementsByTagName(container, "*")...)
+	}
+
+	var blocks []*html.Node
+	ps.forEachNode(nodes, func(node *html.Node, _ int) {
+		// Skip block that nested inside the found one.
+		if ps.someNode(blocks, func(block *html.Node) bool { return isAncestor(block, node) }) {
+			return
+		}
+
+		switch dom.TagName(node) {
+		case "div", "section", "aside", "p":
+		default:
+			return
+		}
+
+		if ps.hasAncestorTag(node, "footer", 0, nil) && !ps.hasAncestorTag(node, "article", 0, nil) {
+			return
+		}
+
+		text := ps.getInnerText(node, true)
+		if text == "" || charCount(text) > maxCorrectionLength {
+			return
+		}
+
+		if rxCorrections.MatchString(dom.ClassName(node)+" "+dom.ID(node)) ||
+			(!ps.hasChildBlockElement(node) && rxCorrectionText.MatchString(text)) {
+			blocks = append(blocks, node)
+		}
+	})
+
+	var corrections []string
+	for _, block := range blocks {
+		corrections = append(corrections, ps.getInnerText(block, true))
+	}
+
+	if ps.StripCorrections {
+		ps.removeNodes(blocks, nil)
+	}
+
+	return corrections
+}
+
+// getCorrectionContainers returns the outermost <article> and <main>
+// of document, or <body> if there are none.
+func (ps *Parser) getCorrectionContainers() []*html.Node {
+	candidates := ps.getAllNodesWithTag(ps.doc, "article", "main")
+	if len(candidates) == 0 {
+		return ps.getAllNodesWithTag(ps.doc, "body")
+	}
+
+	var containers []*html.Node
+	for _, candidate := range candidates {
+		if !ps.someNode(candidates, func(other *html.Node) bool { return isAncestor(other, candidate) }) {
+			containers = append(containers, candidate)
+		}
+	}
+	return containers
+}
@@ -0,0 +1,59 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_extractCorrections(t *testing.T) {
+	rawHTML := `<html><body><article>` +
+		`<p>Editor's note: This story contains graphic descriptions.</p>` +
+		testParagraph + testParagraph +
+		`<p>The correction of the data was needed, the researchers said.</p>` +
+		`<div class="correction">An earlier version of this article misstated the date of the vote.</div>` +
+		`</article><footer><div class="corrections-policy"><a href="/corrections">Corrections policy</a></div>` +
+		`<p>Correction: report errors to the newsroom.</p></footer></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	if article.Corrections != nil {
+		t.Errorf("\nwant no corrections by default, got %q", article.Corrections)
+	}
+
+	parser.ExtractCorrections = true
+	article = parseHTMLString(t, parser, rawHTML)
+
+	expected := []string{
+		"Editor's note: This story contains graphic descriptions.",
+		"An earlier version of this article misstated the date of the vote.",
+	}
+
+	if !reflect.DeepEqual(article.Corrections, expected) {
+		t.Errorf("\n"+
+			"want : %q\n"+
+			"got  : %q", expected, article.Corrections)
+	}
+
+	if !strings.Contains(article.TextContent, "Editor's note") {
+		t.Errorf("\nwant corrections kept in content by default, got %.80q", article.TextContent)
+	}
+
+	// Without article container, the site footer is still skipped.
+	noArticleHTML := strings.Replace(strings.Replace(rawHTML, "<article>", "<div>", 1), "</article>", "</div>", 1)
+	if article := parseHTMLString(t, parser, noArticleHTML); !reflect.DeepEqual(article.Corrections, expected) {
+		t.Errorf("\n"+
+			"want : %q\n"+
+			"got  : %q", expected, article.Corrections)
+	}
+
+	parser.StripCorrections = true
+	article = parseHTMLString(t, parser, rawHTML)
+	if strings.Contains(article.TextContent, "Editor's note") || strings.Contains(article.TextContent, "misstated") {
+		t.Errorf("\nwant corrections removed from content, got %q", article.TextContent)
+	}
+
+	if !strings.Contains(article.TextContent, "The correction of the data") {
+		t.Errorf("\nwant normal paragraph to be kept, got %q", article.TextContent)
+	}
+}
@@ -0,0 +1,88 @@
+package readability
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rxISO8601 matches the date in ISO 8601, in either extended (with
+// separators) or basic format. The date might be calendar date
+// (2006-01-02), week date (2006-W01-1) or ordinal date (2006-002),
+// followed by optional time with fractional seconds and time zone.
+var rxISO8601 = regexp.MustCompile(`^(\d{4})(?:-?(\d{2})-?(\d{2})|-?W(\d{2})(?:-?([1-7]))?|-?(\d{3}))` +
+	`(?:[T ](\d{2})(?::?(\d{2})(?::?(\d{2})(?:[.,](\d+))?)?)?\s*(Z|[+-]\d{2}(?::?\d{2})?)?)?$`)
+
+// parseISO8601 parses date string in ISO 8601, including the forms that
+// time.Parse can't handle with a single layout, e.g. fractional seconds
+// of any precision, week dates and the basic format (20060102T150405Z).
+// Date without time zone is assumed to be in UTC.
+func parseISO8601(str string) (time.Time, bool) {
+	parts := rxISO8601.FindStringSubmatch(str)
+	if parts == nil {
+		return time.Time{}, false
+	}
+
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	year := atoi(parts[1])
+	hour, minute, second := atoi(parts[7]), atoi(parts[8]), atoi(parts[9])
+	if hour > 23 || minute > 59 || second > 59 {
+		return time.Time{}, false
+	}
+
+	nanosecond := 0
+	if fraction := parts[10]; fraction != "" {
+		if len(fraction) > 9 {
+			fraction = fraction[:9]
+		}
+		nanosecond = atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+	}
+
+	location := time.UTC
+	if zone := strings.Replace(parts[11], ":", "", 1); zone != "" && zone != "Z" {
+		offset := atoi(zone[1:3]) * 3600
+		if len(zone) == 5 {
+			offset += atoi(zone[3:5]) * 60
+		}
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		location = time.FixedZone("", offset)
+	}
+
+	var date time.Time
+	switch {
+	case parts[2] != "":
+		month, day := atoi(parts[2]), atoi(parts[3])
+		date = time.Date(year, time.Month(month), day, hour, minute, second, nanosecond, location)
+		if date.Month() != time.Month(month) || date.Day() != day {
+			return time.Time{}, false
+		}
+
+	case parts[4] != "":
+		// Week 1 is the week that contains January 4th, and weeks start
+		// on Monday.
+		week, weekday := atoi(parts[4]), atoi(strOr(parts[5], "1"))
+		if week < 1 || week > 53 {
+			return time.Time{}, false
+		}
+
+		jan4 := time.Date(year, time.January, 4, hour, minute, second, nanosecond, location)
+		firstMonday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		date = firstMonday.AddDate(0, 0, (week-1)*7+weekday-1)
+
+	default:
+		yearDay := atoi(parts[6])
+		date = time.Date(year, time.January, yearDay, hour, minute, second, nanosecond, location)
+		if yearDay < 1 || date.Year() != year {
+			return time.Time{}, false
+		}
+	}
+
+	return date, true
+}
@@ -0,0 +1,50 @@
+package readability
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseISO8601(t *testing.T) {
+	scenarios := []struct {
+		str  string
+		want time.Time
+	}{
+		{"2006-01-02T15:04:05.123Z", time.Date(2006, 1, 2, 15, 4, 5, 123000000, time.UTC)},
+		{"2006-01-02T15:04:05.123456Z", time.Date(2006, 1, 2, 15, 4, 5, 123456000, time.UTC)},
+		{"2006-01-02T15:04:05.123456789123+07:00", time.Date(2006, 1, 2, 8, 4, 5, 123456789, time.UTC)},
+		{"2006-01-02T15:04:05,5+0700", time.Date(2006, 1, 2, 8, 4, 5, 500000000, time.UTC)},
+		{"2006-01-02T15:04-03", time.Date(2006, 1, 2, 18, 4, 0, 0, time.UTC)},
+		{"20060102T150405Z", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"20060102T150405.250+0100", time.Date(2006, 1, 2, 14, 4, 5, 250000000, time.UTC)},
+		{"20060102", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2006-W01-2", time.Date(2006, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2009W537", time.Date(2010, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2008-W01", time.Date(2007, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{"2006-032", time.Date(2006, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"2006032T10:00Z", time.Date(2006, 2, 1, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, scenario := range scenarios {
+		got, ok := parseISO8601(scenario.str)
+		if !ok {
+			t.Errorf("\n%q: failed to parse", scenario.str)
+			continue
+		}
+
+		if !got.Equal(scenario.want) {
+			t.Errorf("\n%q: want %v, got %v", scenario.str, scenario.want, got)
+		}
+
+		// Make sure the dates are accepted by ParseDate as well.
+		if got, ok := ParseDate(scenario.str); !ok || !got.Equal(scenario.want) {
+			t.Errorf("\nParseDate %q: want %v, got %v", scenario.str, scenario.want, got)
+		}
+	}
+
+	for _, str := range []string{"2006-13-01", "2006-02-30", "2006-W54-1", "2006-000", "2007-366", "2006-01-02T25:00Z", "2006-01-02T"} {
+		if got, ok := parseISO8601(str); ok {
+			t.Errorf("\n%q: want failure, got %v", str, got)
+		}
+	}
+}
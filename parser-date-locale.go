@@ -0,0 +1,115 @@
+package readability
+
+import (
+	"regexp"
+	"strings"
+)
+
+var rxDayWithDot = regexp.MustCompile(`^\d{1,2}\.$`)
+
+// dateLocaleNames maps the lowercase month and weekday names of a
+// language into English, so the date can be parsed using dateFormats.
+// Weekday is mapped into empty string, which means it's removed since
+// it's not needed to know the date.
+var dateLocaleNames = map[string]map[string]string{
+	"de": {
+		"januar": "January", "jänner": "January", "februar": "February", "märz": "March",
+		"april": "April", "mai": "May", "juni": "June", "juli": "July", "august": "August",
+		"september": "September", "oktober": "October", "november": "November", "dezember": "December",
+		"jan": "Jan", "feb": "Feb", "mär": "Mar", "apr": "Apr", "jun": "Jun", "jul": "Jul",
+		"aug": "Aug", "sep": "Sep", "sept": "Sep", "okt": "Oct", "nov": "Nov", "dez": "Dec",
+		"montag": "", "dienstag": "", "mittwoch": "", "donnerstag": "", "freitag": "", "samstag": "", "sonntag": "",
+		"mo": "", "di": "", "mi": "", "do": "", "fr": "", "sa": "", "so": "",
+	},
+	"fr": {
+		"janvier": "January", "février": "February", "mars": "March", "avril": "April",
+		"mai": "May", "juin": "June", "juillet": "July", "août": "August", "septembre": "September",
+		"octobre": "October", "novembre": "November", "décembre": "December",
+		"janv": "Jan", "févr": "Feb", "fév": "Feb", "avr": "Apr", "juil": "Jul",
+		"sept": "Sep", "oct": "Oct", "nov": "Nov", "déc": "Dec",
+		"lundi": "", "mardi": "", "mercredi": "", "jeudi": "", "vendredi": "", "samedi": "", "dimanche": "",
+		"le": "", "1er": "1",
+	},
+	"es": {
+		"enero": "January", "febrero": "February", "marzo": "March", "abril": "April",
+		"mayo": "May", "junio": "June", "julio": "July", "agosto": "August", "septiembre": "September",
+		"setiembre": "September", "octubre": "October", "noviembre": "November", "diciembre": "December",
+		"ene": "Jan", "feb": "Feb", "mar": "Mar", "abr": "Apr", "may": "May", "jun": "Jun",
+		"jul": "Jul", "ago": "Aug", "sep": "Sep", "sept": "Sep", "oct": "Oct", "nov": "Nov", "dic": "Dec",
+		"lunes": "", "martes": "", "miércoles": "", "jueves": "", "viernes": "", "sábado": "", "domingo": "",
+		"de": "", "del": "",
+	},
+	"it": {
+		"gennaio": "January", "febbraio": "February", "marzo": "March", "aprile": "April",
+		"maggio": "May", "giugno": "June", "luglio": "July", "agosto": "August", "settembre": "September",
+		"ottobre": "October", "novembre": "November", "dicembre": "December",
+		"gen": "Jan", "feb": "Feb", "mar": "Mar", "apr": "Apr", "mag": "May", "giu": "Jun",
+		"lug": "Jul", "ago": "Aug", "set": "Sep", "ott": "Oct", "nov": "Nov", "dic": "Dec",
+		"lunedì": "", "martedì": "", "mercoledì": "", "giovedì": "", "venerdì": "", "sabato": "", "domenica": "",
+	},
+	"pt": {
+		"janeiro": "January", "fevereiro": "February", "março": "March", "abril": "April",
+		"maio": "May", "junho": "June", "julho": "July", "agosto": "August", "setembro": "September",
+		"outubro": "October", "novembro": "November", "dezembro": "December",
+		"jan": "Jan", "fev": "Feb", "mar": "Mar", "abr": "Apr", "mai": "May", "jun": "Jun",
+		"jul": "Jul", "ago": "Aug", "set": "Sep", "out": "Oct", "nov": "Nov", "dez": "Dec",
+		"segunda-feira": "", "terça-feira": "", "quarta-feira": "", "quinta-feira": "", "sexta-feira": "",
+		"sábado": "", "domingo": "",
+		"de": "",
+	},
+	"nl": {
+		"januari": "January", "februari": "February", "maart": "March", "april": "April",
+		"mei": "May", "juni": "June", "juli": "July", "augustus": "August", "september": "September",
+		"oktober": "October", "november": "November", "december": "December",
+		"jan": "Jan", "feb": "Feb", "mrt": "Mar", "apr": "Apr", "jun": "Jun", "jul": "Jul",
+		"aug": "Aug", "sep": "Sep", "sept": "Sep", "okt": "Oct", "nov": "Nov", "dec": "Dec",
+		"maandag": "", "dinsdag": "", "woensdag": "", "donderdag": "", "vrijdag": "", "zaterdag": "", "zondag": "",
+	},
+}
+
+// translateDateNames translates the month names in date string from the
+// specified language into English, and removes the weekday names and
+// the connecting words (e.g. "de" in Spanish), so "1. Mai 2023" becomes
+// "1 May 2023". Returns the string as it is if the language is unknown.
+func translateDateNames(dateStr string, lang string) string {
+	names, exist := dateLocaleNames[lang]
+	if !exist {
+		return dateStr
+	}
+
+	var words []string
+	for _, word := range strings.Fields(dateStr) {
+		key := strings.ToLower(strings.TrimRight(word, ".,"))
+		if english, isName := names[key]; isName {
+			if english != "" {
+				if strings.HasSuffix(word, ",") {
+					english += ","
+				}
+				words = append(words, english)
+			}
+			continue
+		}
+
+		// German writes the day as ordinal, e.g. "1. Mai".
+		if rxDayWithDot.MatchString(word) {
+			word = strings.TrimSuffix(word, ".")
+		}
+
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// dateLocale returns the language used to parse the non-English dates,
+// which is Parser.DateLocale or the language declared by the document.
+func (ps *Parser) dateLocale() string {
+	if ps.DateLocale != "" {
+		return baseLanguage(ps.DateLocale)
+	}
+
+	if ps.doc == nil {
+		return ""
+	}
+	return baseLanguage(ps.getDocumentLanguage())
+}
@@ -0,0 +1,74 @@
+package readability
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_translateDateNames(t *testing.T) {
+	scenarios := []struct {
+		lang string
+		str  string
+		want time.Time
+	}{
+		{"de", "1. Mai 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"de", "Montag, 1. Mai 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"de", "24. Dez. 2022", time.Date(2022, 12, 24, 0, 0, 0, 0, time.UTC)},
+		{"fr", "1 mai 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"fr", "le lundi 1er mai 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"fr", "14 févr. 2023", time.Date(2023, 2, 14, 0, 0, 0, 0, time.UTC)},
+		{"es", "1 de mayo de 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"pt", "1 de maio de 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"it", "1 maggio 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"nl", "1 mei 2023", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, scenario := range scenarios {
+		got, ok := ParseDate(translateDateNames(scenario.str, scenario.lang))
+		if !ok {
+			t.Errorf("\n%s %q: failed to parse", scenario.lang, scenario.str)
+			continue
+		}
+
+		if !got.Equal(scenario.want) {
+			t.Errorf("\n%s %q: want %v, got %v", scenario.lang, scenario.str, scenario.want, got)
+		}
+	}
+
+	if got := translateDateNames("1. Mai 2023", "xx"); got != "1. Mai 2023" {
+		t.Errorf("\nwant unknown language untouched, got %q", got)
+	}
+}
+
+func Test_dateLocale(t *testing.T) {
+	expected := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		name       string
+		lang       string
+		dateLocale string
+		date       string
+		expected   *time.Time
+	}{
+		{"german document", "de", "", "1. Mai 2023", &expected},
+		{"french document", "fr-FR", "", "1 mai 2023", &expected},
+		{"option over document", "en", "de", "1. Mai 2023", &expected},
+		{"unknown language", "", "", "1. Mai 2023", nil},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html lang="` + scenario.lang + `"><head>` +
+			`<meta property="article:published_time" content="` + scenario.date + `">` +
+			`</head><body><article>` + testParagraph + testParagraph + `</article></body></html>`
+
+		parser := NewParser()
+		parser.DateLocale = scenario.dateLocale
+		article := parseHTMLString(t, parser, rawHTML)
+
+		switch {
+		case scenario.expected == nil && article.PublishedTime != nil:
+			t.Errorf("\n%s: want no published time, got %v", scenario.name, article.PublishedTime)
+		case scenario.expected != nil && (article.PublishedTime == nil || !article.PublishedTime.Equal(*scenario.expected)):
+			t.Errorf("\n%s: want published time %v, got %v", scenario.name, scenario.expected, article.PublishedTime)
+		}
+	}
+}
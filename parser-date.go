@@ -0,0 +1,325 @@
+package readability
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+var (
+	rxTimeModified  = regexp.MustCompile(`(?i)modified|updated`)
+	rxTimePublished = regexp.MustCompile(`(?i)published|posted|created`)
+)
+
+// dateFormats is the date formats that have been seen in the wild.
+var dateFormats = []string{
+	time.RFC822,  // RSS
+	time.RFC822Z, // RSS
+	time.RFC3339, // Atom
+	time.UnixDate,
+	time.RubyDate,
+	time.RFC850,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.ANSIC,
+	"Mon, January 2 2006 15:04:05 -0700",
+	"Mon, January 02, 2006, 15:04:05 MST",
+	"Mon, January 02, 2006 15:04:05 MST",
+	"Mon, Jan 2, 2006 15:04 MST",
+	"Mon, Jan 2 2006 15:04 MST",
+	"Mon, Jan 2, 2006 15:04:05 MST",
+	"Mon, Jan 2 2006 15:04:05 -700",
+	"Mon, Jan 2 2006 15:04:05 -0700",
+	"Mon Jan 2 15:04 2006",
+	"Mon Jan 2 15:04:05 2006 MST",
+	"Mon Jan 02, 2006 3:04 pm",
+	"Mon, Jan 02,2006 15:04:05 MST",
+	"Mon Jan 02 2006 15:04:05 -0700",
+	"Monday, January 2, 2006 15:04:05 MST",
+	"Monday, January 2, 2006 03:04 PM",
+	"Monday, January 2, 2006",
+	"Monday, January 02, 2006",
+	"Monday, 2 January 2006 15:04:05 MST",
+	"Monday, 2 January 2006 15:04:05 -0700",
+	"Monday, 2 Jan 2006 15:04:05 MST",
+	"Monday, 2 Jan 2006 15:04:05 -0700",
+	"Monday, 02 January 2006 15:04:05 MST",
+	"Monday, 02 January 2006 15:04:05 -0700",
+	"Monday, 02 January 2006 15:04:05",
+	"Mon, 2 January 2006 15:04 MST",
+	"Mon, 2 January 2006, 15:04 -0700",
+	"Mon, 2 January 2006, 15:04:05 MST",
+	"Mon, 2 January 2006 15:04:05 MST",
+	"Mon, 2 January 2006 15:04:05 -0700",
+	"Mon, 2 January 2006",
+	"Mon, 2 Jan 2006 3:04:05 PM -0700",
+	"Mon, 2 Jan 2006 15:4:5 MST",
+	"Mon, 2 Jan 2006 15:4:5 -0700 GMT",
+	"Mon, 2, Jan 2006 15:4",
+	"Mon, 2 Jan 2006 15:04 MST",
+	"Mon, 2 Jan 2006, 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 2006 15:04:05 UT",
+	"Mon, 2 Jan 2006 15:04:05MST",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon 2 Jan 2006 15:04:05 MST",
+	"mon,2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04:05 -0700 MST",
+	"Mon, 2 Jan 2006 15:04:05-0700",
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05",
+	"Mon, 2 Jan 2006 15:04",
+	"Mon,2 Jan 2006",
+	"Mon, 2 Jan 2006",
+	"Mon, 2 Jan 15:04:05 MST",
+	"Mon, 2 Jan 06 15:04:05 MST",
+	"Mon, 2 Jan 06 15:04:05 -0700",
+	"Mon, 2006-01-02 15:04",
+	"Mon,02 January 2006 14:04:05 MST",
+	"Mon, 02 January 2006",
+	"Mon, 02 Jan 2006 3:04:05 PM MST",
+	"Mon, 02 Jan 2006 15 -0700",
+	"Mon,02 Jan 2006 15:04 MST",
+	"Mon, 02 Jan 2006 15:04 MST",
+	"Mon, 02 Jan 2006 15:04 -0700",
+	"Mon, 02 Jan 2006 15:04:05 Z",
+	"Mon, 02 Jan 2006 15:04:05 UT",
+	"Mon, 02 Jan 2006 15:04:05 MST-07:00",
+	"Mon, 02 Jan 2006 15:04:05 MST -0700",
+	"Mon, 02 Jan 2006, 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04:05MST",
+	"Mon, 02 Jan 2006 15:04:05 MST",
+	"Mon , 02 Jan 2006 15:04:05 MST",
+	"Mon, 02 Jan 2006 15:04:05 GMT-0700",
+	"Mon,02 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 2006 15:04:05 -07:00",
+	"Mon, 02 Jan 2006 15:04:05 --0700",
+	"Mon 02 Jan 2006 15:04:05 -0700",
+	"Mon, 02 Jan 2006 15:04:05 -07",
+	"Mon, 02 Jan 2006 15:04:05 00",
+	"Mon, 02 Jan 2006 15:04:05",
+	"Mon, 02 Jan 2006",
+	"Mon, 02 Jan 06 15:04:05 MST",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006, 3:04 p.m.",
+	"January 2, 2006 15:04:05 MST",
+	"January 2, 2006 15:04:05",
+	"January 2, 2006 03:04 PM",
+	"January 2, 2006",
+	"January 02, 2006 15:04:05 MST",
+	"January 02, 2006 15:04",
+	"January 02, 2006 03:04 PM",
+	"January 02, 2006",
+	"Jan 2, 2006 3:04:05 PM MST",
+	"Jan 2, 2006 3:04:05 PM",
+	"Jan 2, 2006 15:04:05 MST",
+	"Jan 2, 2006",
+	"Jan 02 2006 03:04:05PM",
+	"Jan 02, 2006",
+	"6/1/2 15:04",
+	"6-1-2 15:04",
+	"2 January 2006 15:04:05 MST",
+	"2 January 2006 15:04:05 -0700",
+	"2 January 2006",
+	"2 Jan 2006 15:04:05 Z",
+	"2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006",
+	"2.1.2006 15:04:05",
+	"2/1/2006",
+	"2-1-2006",
+	"2006 January 02",
+	"2006-1-2T15:04:05Z",
+	"2006-1-2 15:04:05",
+	"2006-1-2",
+	"2006-1-02T15:04:05Z",
+	"2006-01-02T15:04Z",
+	"2006-01-02T15:04-07:00",
+	"2006-01-02T15:04:05Z",
+	"2006-01-02T15:04:05-07:00:00",
+	"2006-01-02T15:04:05:-0700",
+	"2006-01-02T15:04:05-0700",
+	"2006-01-02T15:04:05-07:00",
+	"2006-01-02T15:04:05 -0700",
+	"2006-01-02T15:04:05:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 at 15:04:05",
+	"2006-01-02 15:04:05Z",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05-0700",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04",
+	"2006-01-02 00:00:00.0 15:04:05.0 -0700",
+	"2006/01/02",
+	"2006-01-02",
+	"15:04 02.01.2006 -0700",
+	"1/2/2006 3:04:05 PM MST",
+	"1/2/2006 3:04:05 PM",
+	"1/2/2006 15:04:05 MST",
+	"1/2/2006",
+	"06/1/2 15:04",
+	"06-1-2 15:04",
+	"02 Monday, Jan 2006 15:04",
+	"02 Jan 2006 15:04 MST",
+	"02 Jan 2006 15:04:05 UT",
+	"02 Jan 2006 15:04:05 MST",
+	"02 Jan 2006 15:04:05 -0700",
+	"02 Jan 2006 15:04:05",
+	"02 Jan 2006",
+	"02/01/2006 15:04 MST",
+	"02-01-2006 15:04:05 MST",
+	"02.01.2006 15:04:05",
+	"02/01/2006 15:04:05",
+	"02.01.2006 15:04",
+	"02/01/2006 - 15:04",
+	"02.01.2006 -0700",
+	"02/01/2006",
+	"02-01-2006",
+	"01/02/2006 3:04 PM",
+	"01/02/2006 15:04:05 MST",
+	"01/02/2006 - 15:04",
+	"01/02/2006",
+	"01-02-2006",
+}
+
+// ParseDate parses date string using the formats that have been seen in
+// the wild, e.g. RSS, Atom, ISO 8601 and the common numeric forms. It's
+// the same parser used for the dates of article. Unix timestamp in
+// seconds (10 digits) or milliseconds (13 digits) is accepted as well,
+// so are ISO 8601 week dates and basic format (e.g. 20060102T150405Z).
+// Returns false if the string doesn't match any of the formats.
+func ParseDate(str string) (time.Time, bool) {
+	str = strings.TrimSpace(str)
+	if rxEpochTimestamp.MatchString(str) {
+		epoch, err := strconv.ParseInt(str, 10, 64)
+		if err == nil {
+			if len(str) == 13 {
+				return time.Unix(epoch/1000, epoch%1000*int64(time.Millisecond)).UTC(), true
+			}
+			return time.Unix(epoch, 0).UTC(), true
+		}
+	}
+
+	if parsedDate, ok := parseISO8601(str); ok {
+		return parsedDate, true
+	}
+
+	for _, format := range dateFormats {
+		parsedDate, err := time.Parse(format, str)
+		if err == nil {
+			return parsedDate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func getParsedDate(dateStr string) *time.Time {
+	if parsedDate, ok := ParseDate(dateStr); ok {
+		return &parsedDate
+	}
+	return nil
+}
+
+func (ps *Parser) getDate(metadata map[string]string, fieldName string) *time.Time {
+	dateStr, ok := metadata[fieldName]
+	if ok && len(dateStr) > 0 {
+		return ps.parseDate(dateStr)
+	}
+	return nil
+}
+
+// parseDate parses the date string found in the page, and logs it if
+// the date is not in any of the known formats. The month names in the
+// language of document (or Parser.DateLocale) are understood as well.
+func (ps *Parser) parseDate(dateStr string) *time.Time {
+	parsedDate := getParsedDate(dateStr)
+	if parsedDate == nil {
+		if lang := ps.dateLocale(); lang != "" && lang != "en" {
+			parsedDate = getParsedDate(translateDateNames(dateStr, lang))
+		}
+	}
+
+	if parsedDate == nil {
+		ps.logf("failed to parse date %q", dateStr)
+	}
+	return parsedDate
+}
+
+// getTimeElementDates returns the published and modified dates declared
+// in <time> elements, preferring their datetime attribute over the text.
+// <time> which explicitly marked (e.g. by itemprop or pubdate) or located
+// in header or byline is preferred over the other ones, while the ones in
+// navigation, sidebar, footer, comments and the other unlikely candidates
+// are ignored since they are likely the dates of other articles or of
+// the comments.
+func (ps *Parser) getTimeElementDates() (string, string) {
+	var published, modified [2]string
+	for _, element := range dom.GetElementsByTagName(ps.doc, "time") {
+		dateStr := strings.TrimSpace(dom.GetAttribute(element, "datetime"))
+		if dateStr == "" {
+			dateStr = strings.TrimSpace(dom.TextContent(element))
+		}
+
+		if dateStr == "" || getParsedDate(dateStr) == nil {
+			continue
+		}
+
+		matchString := dom.GetAttribute(element, "itemprop") + " " + dom.GetAttribute(element, "class")
+		tier, ok := ps.getTimeElementTier(element, matchString)
+		if !ok {
+			continue
+		}
+
+		dates := &published
+		if rxTimeModified.MatchString(matchString) {
+			dates = &modified
+		}
+
+		if dates[tier] == "" {
+			dates[tier] = dateStr
+		}
+	}
+
+	return strOr(published[0], published[1]), strOr(modified[0], modified[1])
+}
+
+// getTimeElementTier returns 0 for <time> which marked or located in the
+// byline context, and 1 for the other ones. Returns false if the element
+// should be ignored, i.e. it's in comments, or it's in navigation, sidebar,
+// footer or other unlikely candidate without any mark or closer byline.
+func (ps *Parser) getTimeElementTier(element *html.Node, matchString string) (int, bool) {
+	tier := 1
+	if dom.HasAttribute(element, "pubdate") || rxTimePublished.MatchString(matchString) ||
+		rxTimeModified.MatchString(matchString) {
+		tier = 0
+	}
+
+	for parent := element.Parent; parent != nil; parent = parent.Parent {
+		switch dom.TagName(parent) {
+		case "nav", "aside", "footer":
+			return 0, false
+		case "header":
+			tier = 0
+		}
+
+		parentMatch := dom.ClassName(parent) + " " + dom.ID(parent) + " " + dom.GetAttribute(parent, "itemprop")
+		switch {
+		case rxCommentsSection.MatchString(parentMatch):
+			return 0, false
+		case rxByline.MatchString(parentMatch):
+			tier = 0
+		case tier != 0 && rxUnlikelyCandidates.MatchString(parentMatch) &&
+			!rxOkMaybeItsACandidate.MatchString(parentMatch):
+			return 0, false
+		}
+	}
+
+	return tier, true
+}
@@ -0,0 +1,115 @@
+package readability
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_ParseDate(t *testing.T) {
+	scenarios := []struct {
+		str  string
+		want time.Time
+	}{
+		{"02 Jan 06 15:04 UTC", time.Date(2006, 1, 2, 15, 4, 0, 0, time.UTC)},
+		{"02 Jan 06 15:04 +0700", time.Date(2006, 1, 2, 8, 4, 0, 0, time.UTC)},
+		{"2006-01-02T15:04:05Z", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2006-01-02T15:04:05+07:00", time.Date(2006, 1, 2, 8, 4, 5, 0, time.UTC)},
+		{"Mon, 02 Jan 2006 15:04:05 -0700", time.Date(2006, 1, 2, 22, 4, 5, 0, time.UTC)},
+		{"2006-01-02 15:04:05", time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"2006-01-02", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{" 2006/01/02 ", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"02 Jan 2006", time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"1700000000", time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)},
+		{"1700000000123", time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC)},
+	}
+
+	for _, scenario := range scenarios {
+		got, ok := ParseDate(scenario.str)
+		if !ok {
+			t.Errorf("\n%q: failed to parse", scenario.str)
+			continue
+		}
+
+		if !got.Equal(scenario.want) {
+			t.Errorf("\n%q: want %v, got %v", scenario.str, scenario.want, got)
+		}
+	}
+
+	for _, str := range []string{"", "yesterday", "2006-13-45", "0170000000", "170000000", "17000000001"} {
+		if got, ok := ParseDate(str); ok {
+			t.Errorf("\n%q: want failure, got %v", str, got)
+		}
+	}
+}
+
+func Test_timeElementDates(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		head      string
+		body      string
+		published string
+		modified  string
+	}{{
+		name:      "datetime attribute",
+		body:      `<header><time datetime="2023-05-01T10:00:00Z">May 1st</time></header>`,
+		published: "2023-05-01T10:00:00Z",
+	}, {
+		name:      "text without datetime",
+		body:      `<p class="byline">By John <time>2023-05-01</time></p>`,
+		published: "2023-05-01T00:00:00Z",
+	}, {
+		name: "byline is preferred",
+		body: `<p>Related: <time datetime="2020-01-01">old post</time></p>` +
+			`<div class="author-info"><time datetime="2023-05-01T10:00:00Z">today</time></div>`,
+		published: "2023-05-01T10:00:00Z",
+	}, {
+		name:      "sidebar is ignored",
+		body:      `<aside><time datetime="2020-01-01">old post</time></aside>`,
+		published: "",
+	}, {
+		name: "modified date",
+		body: `<header><time datetime="2023-05-01T10:00:00Z">May 1st</time>` +
+			`<time itemprop="dateModified" datetime="2023-05-02T12:00:00Z">May 2nd</time></header>`,
+		published: "2023-05-01T10:00:00Z",
+		modified:  "2023-05-02T12:00:00Z",
+	}, {
+		name: "comment is ignored",
+		body: `<div class="comments"><div class="comment"><p>Great read!</p>` +
+			`<time datetime="2020-01-01T10:00:00Z">Jan 1st</time></div></div>`,
+		published: "",
+	}, {
+		name:      "related posts are ignored",
+		body:      `<div class="related-posts"><time datetime="2020-01-01T10:00:00Z">Jan 1st</time></div>`,
+		published: "",
+	}, {
+		name:      "marked date in unlikely header",
+		body:      `<div class="post-header"><time itemprop="datePublished" datetime="2023-05-01T10:00:00Z">May 1st</time></div>`,
+		published: "2023-05-01T10:00:00Z",
+	}, {
+		name:      "meta is preferred",
+		head:      `<meta property="article:published_time" content="2022-01-01T00:00:00Z">`,
+		body:      `<header><time datetime="2023-05-01T10:00:00Z">May 1st</time></header>`,
+		published: "2022-01-01T00:00:00Z",
+	}}
+
+	formatDate := func(date *time.Time) string {
+		if date == nil {
+			return ""
+		}
+		return date.UTC().Format(time.RFC3339)
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head>` + scenario.head + `</head><body><article>` +
+			scenario.body + testParagraph + testParagraph + `</article></body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+
+		if got := formatDate(article.PublishedTime); got != scenario.published {
+			t.Errorf("\n%s: want published %q, got %q", scenario.name, scenario.published, got)
+		}
+
+		if got := formatDate(article.ModifiedTime); got != scenario.modified {
+			t.Errorf("\n%s: want modified %q, got %q", scenario.name, scenario.modified, got)
+		}
+	}
+}
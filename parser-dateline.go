@@ -0,0 +1,107 @@
+package readability
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Dateline is the place, news agency and date which written at the
+// start of news article, e.g. "LONDON, Feb 15 (Reuters) -".
+type Dateline struct {
+	Place  string
+	Agency string
+	Date   *time.Time
+}
+
+// datelineDateFormats is formats of the date inside dateline, which
+// usually doesn't have the year.
+var datelineDateFormats = []string{
+	"Jan 2, 2006",
+	"January 2, 2006",
+	"Jan 2",
+	"January 2",
+}
+
+// extractDateline looks for dateline at the start of the first paragraph
+// in article content. The date in dateline usually doesn't have year,
+// so the year is taken from publishedTime or the current time. If
+// StripDateline is enabled, the dateline will be removed from content.
+func (ps *Parser) extractDateline(articleContent *html.Node, publishedTime *time.Time) *Dateline {
+	paragraphs := dom.GetElementsByTagName(articleContent, "p")
+	if len(paragraphs) == 0 {
+		return nil
+	}
+
+	paragraph := paragraphs[0]
+	parts := rxDateline.FindStringSubmatch(dom.TextContent(paragraph))
+	if parts == nil {
+		return nil
+	}
+
+	dateline := &Dateline{
+		Place:  strings.TrimSpace(parts[1]),
+		Agency: strings.TrimSpace(parts[3]),
+	}
+
+	if strDate := strings.Replace(strings.TrimSpace(parts[2]), ".", "", -1); strDate != "" {
+		for _, format := range datelineDateFormats {
+			date, err := time.Parse(format, strDate)
+			if err != nil {
+				continue
+			}
+
+			if date.Year() == 0 {
+				year := time.Now().Year()
+				if publishedTime != nil {
+					year = publishedTime.Year()
+				} else if ps.Now != nil {
+					year = ps.Now().Year()
+				}
+				date = date.AddDate(year, 0, 0)
+			}
+
+			dateline.Date = &date
+			break
+		}
+	}
+
+	if ps.StripDateline {
+		ps.stripTextPrefix(paragraph, len(parts[0]))
+	}
+
+	return dateline
+}
+
+// stripTextPrefix removes the first n bytes of text content in node,
+// then removes the inline elements that left empty because of it.
+func (ps *Parser) stripTextPrefix(node *html.Node, n int) {
+	var emptied []*html.Node
+	var stripper func(*html.Node)
+
+	stripper = func(node *html.Node) {
+		for child := node.FirstChild; child != nil && n > 0; child = child.NextSibling {
+			if child.Type == html.TextNode {
+				if len(child.Data) <= n {
+					n -= len(child.Data)
+					child.Data = ""
+				} else {
+					child.Data = child.Data[n:]
+					n = 0
+				}
+			} else {
+				stripper(child)
+			}
+
+			if child.Type == html.ElementNode && len(dom.Children(child)) == 0 &&
+				strings.TrimSpace(dom.TextContent(child)) == "" && dom.TagName(child) != "img" {
+				emptied = append(emptied, child)
+			}
+		}
+	}
+
+	stripper(node)
+	ps.removeNodes(emptied, nil)
+}
@@ -0,0 +1,68 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_extractDateline(t *testing.T) {
+	paragraph := strings.Repeat(testSentence, 12)
+	scenarios := map[string]Dateline{
+		"LONDON, Feb 15 (Reuters) — ":    {Place: "LONDON", Agency: "Reuters"},
+		"WASHINGTON (AP) - ":             {Place: "WASHINGTON", Agency: "AP"},
+		"<strong>NEW YORK</strong> — ":   {Place: "NEW YORK"},
+		"The LONDON office said that - ": {},
+		"WASHINGTON—":                    {Place: "WASHINGTON"},
+		"FBI-led raid in the city ":      {},
+		"US-China talks resumed ":        {},
+		"US–China talks resumed ":        {},
+		"COVID-19 cases rose ":           {},
+	}
+
+	for prefix, expected := range scenarios {
+		rawHTML := "<html><head><meta property=\"article:published_time\" content=\"2019-03-01T00:00:00Z\"></head>" +
+			"<body><article><p>" + prefix + paragraph + "</p></article></body></html>"
+
+		parser := NewParser()
+		parser.StripDateline = true
+		article := parseHTMLString(t, parser, rawHTML)
+
+		if expected.Place == "" {
+			if article.Dateline != nil {
+				t.Errorf("\nprefix %q: want no dateline, got %+v", prefix, article.Dateline)
+			}
+			continue
+		}
+
+		if article.Dateline == nil {
+			t.Errorf("\nprefix %q: want dateline, got nil", prefix)
+			continue
+		}
+
+		if article.Dateline.Place != expected.Place || article.Dateline.Agency != expected.Agency {
+			t.Errorf("\n"+
+				"prefix : %q\n"+
+				"want   : %s (%s)\n"+
+				"got    : %s (%s)", prefix,
+				expected.Place, expected.Agency,
+				article.Dateline.Place, article.Dateline.Agency)
+		}
+
+		if !strings.HasPrefix(article.TextContent, "Lorem ipsum") {
+			t.Errorf("\nprefix %q: want dateline stripped, got %q", prefix, article.TextContent[:40])
+		}
+	}
+
+	// Date without year should use the year of published time
+	rawHTML := "<html><head><meta property=\"article:published_time\" content=\"2019-03-01T00:00:00Z\"></head>" +
+		"<body><article><p>LONDON, Feb 15 (Reuters) - " + paragraph + "</p></article></body></html>"
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if article.Dateline == nil || article.Dateline.Date == nil ||
+		article.Dateline.Date.Format("2006-01-02") != "2019-02-15" {
+		t.Errorf("\nwant dateline date 2019-02-15, got %+v", article.Dateline)
+	}
+
+	if !strings.HasPrefix(article.TextContent, "LONDON") {
+		t.Errorf("\nwant dateline kept when StripDateline disabled")
+	}
+}
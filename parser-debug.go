@@ -0,0 +1,68 @@
+package readability
+
+import (
+	"context"
+	"io"
+	nurl "net/url"
+
+	"golang.org/x/net/html"
+)
+
+// DebugInfo describes how the article content is extracted, which helps
+// to understand why a page is extracted poorly.
+type DebugInfo struct {
+	// Attempts is the attempts of grabbing the article content, in the
+	// order they're made. When an attempt yields less text than
+	// Parser.CharThresholds, one of the flags is turned off and the
+	// content is grabbed again.
+	Attempts []DebugAttempt
+	// RootSelectionMethod is how the root of article content is chosen.
+	// See the RootSelection constants for the possible values.
+	RootSelectionMethod string
+}
+
+// DebugAttempt is a single attempt of grabbing the article content.
+type DebugAttempt struct {
+	// StripUnlikelys tells whether the elements which unlikely to be
+	// content (e.g. comments and sidebars) are removed before scoring.
+	StripUnlikelys bool
+	// UseWeightClasses tells whether class and id are used to weight
+	// the score of elements.
+	UseWeightClasses bool
+	// CleanConditionally tells whether the suspicious elements (e.g.
+	// link-dense lists) are removed from the content.
+	CleanConditionally bool
+	// TextLength is the number of chars of the grabbed content.
+	TextLength int
+	// Selected tells whether the content of this attempt is used as the
+	// article content.
+	Selected bool
+}
+
+// ParseDebug is like Parse, but it also returns the information about
+// how the article content is extracted.
+func (ps *Parser) ParseDebug(input io.Reader, pageURL *nurl.URL) (Article, DebugInfo, error) {
+	var debug DebugInfo
+	article, err := ps.parse(context.Background(), input, pageURL, &debug)
+	return article, debug, err
+}
+
+// debugInfo returns the debug information of current parse, where
+// articleContent is the content returned by grabArticle.
+func (ps *Parser) debugInfo(articleContent *html.Node) DebugInfo {
+	attempts := make([]DebugAttempt, len(ps.attempts))
+	for i, attempt := range ps.attempts {
+		attempts[i] = DebugAttempt{
+			StripUnlikelys:     attempt.flags.stripUnlikelys,
+			UseWeightClasses:   attempt.flags.useWeightClasses,
+			CleanConditionally: attempt.flags.cleanConditionally,
+			TextLength:         attempt.textLength,
+			Selected:           articleContent != nil && attempt.articleContent == articleContent,
+		}
+	}
+
+	return DebugInfo{
+		Attempts:            attempts,
+		RootSelectionMethod: ps.rootSelectionMethod,
+	}
+}
@@ -0,0 +1,58 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_ParseDebug(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+
+	// The content is wrapped in an unlikely candidate, so it's stripped
+	// in the first attempt and only found after some flags are off.
+	rawHTML := `<html><body><div class="comment-wrapper">` + testParagraph + testParagraph + `</div></body></html>`
+
+	parser := NewParser()
+	article, debug, err := parser.ParseDebug(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse HTML: %v", err)
+	}
+
+	if len(debug.Attempts) < 2 {
+		t.Fatalf("\nwant several attempts, got %+v", debug.Attempts)
+	}
+
+	first := debug.Attempts[0]
+	if !first.StripUnlikelys || !first.UseWeightClasses || !first.CleanConditionally {
+		t.Errorf("\nfirst attempt should use all flags, got %+v", first)
+	}
+
+	if first.TextLength >= parser.CharThresholds || first.Selected {
+		t.Errorf("\nfirst attempt should yield too little text, got %+v", first)
+	}
+
+	if second := debug.Attempts[1]; second.StripUnlikelys || !second.UseWeightClasses || !second.CleanConditionally {
+		t.Errorf("\nsecond attempt should only turn off stripUnlikelys, got %+v", second)
+	}
+
+	last := debug.Attempts[len(debug.Attempts)-1]
+	if last.TextLength < parser.CharThresholds || !last.Selected {
+		t.Errorf("\nlast attempt should be selected, got %+v", last)
+	}
+
+	if debug.RootSelectionMethod != article.RootSelectionMethod {
+		t.Errorf("\nwant root selection method %q, got %q", article.RootSelectionMethod, debug.RootSelectionMethod)
+	}
+
+	// Content that passes the threshold right away only needs one attempt.
+	rawHTML = articleHTML("", "")
+	_, debug, err = parser.ParseDebug(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse HTML: %v", err)
+	}
+
+	if len(debug.Attempts) != 1 || !debug.Attempts[0].Selected {
+		t.Errorf("\nwant 1 selected attempt, got %+v", debug.Attempts)
+	}
+}
@@ -0,0 +1,82 @@
+package readability
+
+import (
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// defaultAllowedEmbedHosts is the hosts of video players whose <iframe>
+// is kept in content by default, the sites that matched by rxVideos plus
+// vimeo.com itself.
+var defaultAllowedEmbedHosts = []string{
+	"youtube.com", "www.youtube.com", "youtube-nocookie.com", "www.youtube-nocookie.com",
+	"vimeo.com", "player.vimeo.com", "dailymotion.com", "www.dailymotion.com", "v.qq.com",
+	"archive.org", "www.archive.org", "upload.wikimedia.org", "player.twitch.tv",
+}
+
+// isAllowedEmbed determines if the embedded element should be kept in
+// content. <iframe> is allowed if the host of its src (or data-src for
+// the lazy-loaded one) is listed in AllowedEmbedHosts, while <embed> and
+// <object> are allowed if they refer to known video sites.
+func (ps *Parser) isAllowedEmbed(element *html.Node) bool {
+	if dom.TagName(element) == "iframe" {
+		for _, attrName := range []string{"src", "data-src"} {
+			if ps.isAllowedEmbedURL(dom.GetAttribute(element, attrName)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// First, check the elements attributes to see if any of them contain
+	// youtube or vimeo
+	for _, attr := range element.Attr {
+		if rxVideos.MatchString(attr.Val) {
+			return true
+		}
+	}
+
+	// For embed with <object> tag, check inner HTML as well.
+	return dom.TagName(element) == "object" && rxVideos.MatchString(dom.InnerHTML(element))
+}
+
+// isAllowedEmbedURL determines if the host of src is listed in
+// AllowedEmbedHosts. The host must be equal to the listed one, unless
+// it's listed with "*." prefix which allows any of its subdomains.
+// Protocol-relative src is resolved before its host is checked.
+func (ps *Parser) isAllowedEmbedURL(src string) bool {
+	src = strings.TrimSpace(src)
+	if src == "" {
+		return false
+	}
+
+	if strings.HasPrefix(src, "//") {
+		scheme := "https"
+		if ps.documentURI != nil && ps.documentURI.Scheme != "" {
+			scheme = ps.documentURI.Scheme
+		}
+		src = scheme + ":" + src
+	}
+
+	parsedURL, err := nurl.Parse(src)
+	if err != nil || parsedURL.Hostname() == "" {
+		return false
+	}
+
+	host := strings.ToLower(parsedURL.Hostname())
+	for _, allowedHost := range ps.AllowedEmbedHosts {
+		allowedHost = strings.ToLower(strings.TrimSpace(allowedHost))
+		if strings.HasPrefix(allowedHost, "*.") {
+			if strings.HasSuffix(host, allowedHost[1:]) {
+				return true
+			}
+		} else if allowedHost != "" && host == allowedHost {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,31 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_allowedEmbedHosts(t *testing.T) {
+	// Only the video iframes are kept by default, as checked by the
+	// embed-hosts test page.
+	parser := NewParser()
+	parser.AllowedEmbedHosts = append(parser.AllowedEmbedHosts, "*.Example.org")
+	article := parseTestPage(t, parser, "embed-hosts")
+	if !strings.Contains(article.Content, "widgets.example.org") {
+		t.Errorf("\nwant iframe of custom host kept, got %s", article.Content)
+	}
+
+	for _, unwanted := range []string{"tracker.example.com", "web.archive.org"} {
+		if strings.Contains(article.Content, unwanted) {
+			t.Errorf("\nwant %s iframe removed, got %s", unwanted, article.Content)
+		}
+	}
+	// Both vimeo.com and its player host are allowed by default.
+	for _, src := range []string{"https://vimeo.com/76979871", "https://player.vimeo.com/video/76979871"} {
+		rawHTML := articleHTML("", `<iframe src="`+src+`"></iframe>`)
+		article = parseHTMLString(t, NewParser(), rawHTML)
+		if !strings.Contains(article.Content, src) {
+			t.Errorf("\nwant %s iframe kept, got %s", src, article.Content)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package readability
+
+import (
+	"golang.org/x/net/html"
+)
+
+// EmphasisNormalization is how the emphasis tags in content normalized.
+type EmphasisNormalization int
+
+// Available emphasis normalizations.
+const (
+	// EmphasisNone keeps the emphasis tags as they are.
+	EmphasisNone EmphasisNormalization = iota
+	// EmphasisSemantic converts <b> into <strong> and <i> into <em>.
+	EmphasisSemantic
+	// EmphasisPresentational converts <strong> into <b> and <em> into <i>.
+	EmphasisPresentational
+)
+
+// normalizeEmphasisTags converts the emphasis tags in article content
+// according to NormalizeEmphasisTags.
+func (ps *Parser) normalizeEmphasisTags(articleContent *html.Node) {
+	var replacements map[string]string
+	switch ps.NormalizeEmphasisTags {
+	case EmphasisSemantic:
+		replacements = map[string]string{"b": "strong", "i": "em"}
+	case EmphasisPresentational:
+		replacements = map[string]string{"strong": "b", "em": "i"}
+	default:
+		return
+	}
+
+	for from, to := range replacements {
+		ps.replaceNodeTags(ps.getAllNodesWithTag(articleContent, from), to)
+	}
+}
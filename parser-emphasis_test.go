@@ -0,0 +1,33 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_normalizeEmphasisTags(t *testing.T) {
+	rawHTML := `<html><body><article>` +
+		`<p>Some <b>bold</b>, <strong>strong</strong>, <i>italic</i> and <em>emphasized</em> words.</p>` +
+		testParagraph + testParagraph + `</article></body></html>`
+
+	scenarios := []struct {
+		normalization EmphasisNormalization
+		expected      string
+	}{
+		{EmphasisNone, `<p>Some <b>bold</b>, <strong>strong</strong>, <i>italic</i> and <em>emphasized</em> words.</p>`},
+		{EmphasisSemantic, `<p>Some <strong>bold</strong>, <strong>strong</strong>, <em>italic</em> and <em>emphasized</em> words.</p>`},
+		{EmphasisPresentational, `<p>Some <b>bold</b>, <b>strong</b>, <i>italic</i> and <i>emphasized</i> words.</p>`},
+	}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.NormalizeEmphasisTags = scenario.normalization
+		article := parseHTMLString(t, parser, rawHTML)
+		if !strings.Contains(article.Content, scenario.expected) {
+			t.Errorf("\n"+
+				"normalization : %d\n"+
+				"want          : %s\n"+
+				"got           : %.200s", scenario.normalization, scenario.expected, article.Content)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package readability
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+)
+
+// EventInfo is the details of event declared in JSON-LD, for articles
+// which list an event, e.g. concert or conference.
+type EventInfo struct {
+	// Name is the name of event.
+	Name string
+	// Start is the time when the event starts.
+	Start *time.Time
+	// End is the time when the event ends. Nil if it's not declared.
+	End *time.Time
+	// Location is the place of event, e.g. "Town Hall, 123 Main St,
+	// Springfield", or the URL of virtual location for online event.
+	Location string
+	// TicketURL is the URL to buy tickets, taken from offers of event.
+	TicketURL string
+}
+
+// getEvent looks for schema.org Event (or its subtypes, e.g. MusicEvent)
+// in the JSON-LD of document. Returns nil if there are none.
+func (ps *Parser) getEvent() *EventInfo {
+	scripts := ps.getAllNodesWithTag(ps.doc, "script")
+	for _, script := range scripts {
+		if dom.GetAttribute(script, "type") != "application/ld+json" {
+			continue
+		}
+
+		var parsed interface{}
+		content := rxCDATA.ReplaceAllString(dom.TextContent(script), "")
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			continue
+		}
+
+		if objEvent := findJSONLDEvent(parsed); objEvent != nil {
+			return ps.toEventInfo(objEvent)
+		}
+	}
+
+	return nil
+}
+
+// findJSONLDEvent finds the first Event object in JSON-LD value, which
+// might be an array of objects or a graph.
+func findJSONLDEvent(value interface{}) map[string]interface{} {
+	switch val := value.(type) {
+	case []interface{}:
+		for _, item := range val {
+			if objEvent := findJSONLDEvent(item); objEvent != nil {
+				return objEvent
+			}
+		}
+
+	case map[string]interface{}:
+		if isJSONLDEvent(val["@type"]) {
+			return val
+		}
+		return findJSONLDEvent(val["@graph"])
+	}
+
+	return nil
+}
+
+// isJSONLDEvent determines if the JSON-LD @type, which might be a single
+// type or list of types, is an Event.
+func isJSONLDEvent(objType interface{}) bool {
+	switch val := objType.(type) {
+	case string:
+		return rxJsonLdEventType.MatchString(val)
+	case []interface{}:
+		for _, item := range val {
+			if isJSONLDEvent(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// toEventInfo converts Event object in JSON-LD into EventInfo.
+func (ps *Parser) toEventInfo(objEvent map[string]interface{}) *EventInfo {
+	event := &EventInfo{
+		Location: getJSONLDLocation(objEvent["location"]),
+	}
+
+	if name, isString := objEvent["name"].(string); isString {
+		event.Name = strings.TrimSpace(name)
+	}
+
+	if startDate, isString := objEvent["startDate"].(string); isString && strings.TrimSpace(startDate) != "" {
+		event.Start = ps.parseDate(startDate)
+	}
+
+	if endDate, isString := objEvent["endDate"].(string); isString && strings.TrimSpace(endDate) != "" {
+		event.End = ps.parseDate(endDate)
+	}
+
+	offers := objEvent["offers"]
+	if listOffers, isArray := offers.([]interface{}); isArray && len(listOffers) > 0 {
+		offers = listOffers[0]
+	}
+
+	if objOffer, isObj := offers.(map[string]interface{}); isObj {
+		if url, isString := objOffer["url"].(string); isString {
+			event.TicketURL = toAbsoluteURI(strings.TrimSpace(url), ps.documentURI)
+		}
+	}
+
+	return event
+}
+
+// getJSONLDLocation returns the location of event in JSON-LD, which
+// might be declared as plain text, Place with its name and address, or
+// VirtualLocation with its URL.
+func getJSONLDLocation(location interface{}) string {
+	switch val := location.(type) {
+	case string:
+		return strings.TrimSpace(val)
+
+	case []interface{}:
+		for _, item := range val {
+			if str := getJSONLDLocation(item); str != "" {
+				return str
+			}
+		}
+
+	case map[string]interface{}:
+		var parts []string
+		if name, isString := val["name"].(string); isString && strings.TrimSpace(name) != "" {
+			parts = append(parts, strings.TrimSpace(name))
+		}
+
+		switch address := val["address"].(type) {
+		case string:
+			parts = append(parts, strings.TrimSpace(address))
+		case map[string]interface{}:
+			for _, key := range []string{"streetAddress", "addressLocality", "addressRegion", "postalCode", "addressCountry"} {
+				if str, isString := address[key].(string); isString && strings.TrimSpace(str) != "" {
+					parts = append(parts, strings.TrimSpace(str))
+				}
+			}
+		}
+
+		if len(parts) == 0 {
+			if url, isString := val["url"].(string); isString {
+				return strings.TrimSpace(url)
+			}
+		}
+
+		return strings.Join(parts, ", ")
+	}
+
+	return ""
+}
@@ -0,0 +1,73 @@
+package readability
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_getEvent(t *testing.T) {
+	body := `<article><h1>Title</h1>` + testParagraph + testParagraph + `</article>`
+
+	t.Run("no event", func(t *testing.T) {
+		rawHTML := `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","headline":"Title"}</script></head>` +
+			`<body>` + body + `</body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Event != nil {
+			t.Errorf("want nil event, got %+v", article.Event)
+		}
+	})
+
+	t.Run("music event", func(t *testing.T) {
+		rawHTML := `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@graph":[` +
+			`{"@type":"WebPage","name":"Page"},` +
+			`{"@type":"MusicEvent","name":"Summer Concert","startDate":"2023-07-01T19:30:00Z","endDate":"Sat, 01 Jul 2023 22:00:00 +0000",` +
+			`"location":{"@type":"Place","name":"Town Hall","address":{"@type":"PostalAddress","streetAddress":"123 Main St","addressLocality":"Springfield"}},` +
+			`"offers":[{"@type":"Offer","url":"/tickets/summer"}]}]}</script></head>` +
+			`<body>` + body + `</body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Event == nil {
+			t.Fatalf("want event, got nil")
+		}
+
+		event := article.Event
+		if event.Name != "Summer Concert" {
+			t.Errorf("want name %q, got %q", "Summer Concert", event.Name)
+		}
+
+		wantStart := time.Date(2023, 7, 1, 19, 30, 0, 0, time.UTC)
+		if event.Start == nil || !event.Start.Equal(wantStart) {
+			t.Errorf("want start %v, got %v", wantStart, event.Start)
+		}
+
+		wantEnd := time.Date(2023, 7, 1, 22, 0, 0, 0, time.UTC)
+		if event.End == nil || !event.End.Equal(wantEnd) {
+			t.Errorf("want end %v, got %v", wantEnd, event.End)
+		}
+
+		if want := "Town Hall, 123 Main St, Springfield"; event.Location != want {
+			t.Errorf("want location %q, got %q", want, event.Location)
+		}
+
+		if want := "http://fakehost/tickets/summer"; event.TicketURL != want {
+			t.Errorf("want ticket URL %q, got %q", want, event.TicketURL)
+		}
+	})
+
+	t.Run("virtual event", func(t *testing.T) {
+		rawHTML := `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@type":"Event","name":"Webinar",` +
+			`"startDate":"2023-07-01","location":{"@type":"VirtualLocation","url":"https://example.com/live"}}</script></head>` +
+			`<body>` + body + `</body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Event == nil {
+			t.Fatalf("want event, got nil")
+		}
+
+		if want := "https://example.com/live"; article.Event.Location != want {
+			t.Errorf("want location %q, got %q", want, article.Event.Location)
+		}
+
+		if article.Event.End != nil {
+			t.Errorf("want nil end, got %v", article.Event.End)
+		}
+	})
+}
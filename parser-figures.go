@@ -0,0 +1,78 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// figureMediaTags is the elements which count as the content of figure,
+// beside the text outside of its caption.
+var figureMediaTags = []string{"img", "picture", "video", "audio", "iframe", "embed", "object", "svg", "canvas"}
+
+// normalizeFigures keeps every <figcaption> attached to its <figure>.
+// Caption which nested deeper in the figure (e.g. inside the wrapper of
+// image) is moved to be the direct child of figure, right after the
+// child that contains the media. Figure whose media has been removed
+// while cleaning, so it only has caption or nothing left, is removed.
+func (ps *Parser) normalizeFigures(articleContent *html.Node) {
+	figures := ps.getAllNodesWithTag(articleContent, "figure")
+	ps.removeNodes(figures, func(figure *html.Node) bool {
+		return !ps.figureHasContent(figure)
+	})
+
+	for _, figure := range ps.getAllNodesWithTag(articleContent, "figure") {
+		for _, caption := range ps.getAllNodesWithTag(figure, "figcaption") {
+			if caption.Parent == figure || ps.closestFigure(caption) != figure {
+				continue
+			}
+
+			// Find the child of figure which contains the caption.
+			container := caption.Parent
+			for container.Parent != figure {
+				container = container.Parent
+			}
+
+			caption.Parent.RemoveChild(caption)
+			figure.InsertBefore(caption, container.NextSibling)
+		}
+	}
+}
+
+// figureHasContent determines if figure still has media, or text outside
+// of its caption.
+func (ps *Parser) figureHasContent(figure *html.Node) bool {
+	for _, media := range ps.getAllNodesWithTag(figure, figureMediaTags...) {
+		if dom.TagName(media) != "img" ||
+			dom.GetAttribute(media, "src") != "" || dom.GetAttribute(media, "srcset") != "" {
+			return true
+		}
+	}
+
+	var hasText bool
+	var finder func(*html.Node)
+	finder = func(node *html.Node) {
+		for child := node.FirstChild; child != nil && !hasText; child = child.NextSibling {
+			switch {
+			case child.Type == html.TextNode:
+				hasText = strings.TrimSpace(child.Data) != ""
+			case dom.TagName(child) != "figcaption":
+				finder(child)
+			}
+		}
+	}
+
+	finder(figure)
+	return hasText
+}
+
+// closestFigure returns the nearest <figure> ancestor of node.
+func (ps *Parser) closestFigure(node *html.Node) *html.Node {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if dom.TagName(parent) == "figure" {
+			return parent
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package readability
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+)
+
+// recentFreshnessWindow is how old an article may be and still
+// be considered as "recent".
+const recentFreshnessWindow = 7 * 24 * time.Hour
+
+// getArticleFreshness classifies how time-sensitive the article is,
+// on top of the publish date that already extracted. The buckets are:
+//
+// "live" is used when the page is a live blog, either declared in
+// JSON-LD as LiveBlogPosting or its containers or content are marked
+// by live blog class names, or
+// when its title or opening line starts with LIVE or BREAKING.
+//
+// "recent" is used when the article is published within 7 days
+// before Parser.Now. Dates in the future are considered recent too.
+//
+// "dated" is used when the article is published more than 7 days
+// before Parser.Now.
+//
+// "evergreen" is used when there are no publish date at all, which
+// is common for reference pages that don't age.
+func (ps *Parser) getArticleFreshness(metadata map[string]string, publishedTime *time.Time, textContent string) string {
+	if ps.isLiveArticle(metadata, textContent) {
+		return "live"
+	}
+
+	if publishedTime == nil {
+		return "evergreen"
+	}
+
+	now := time.Now
+	if ps.Now != nil {
+		now = ps.Now
+	}
+
+	if now().Sub(*publishedTime) <= recentFreshnessWindow {
+		return "recent"
+	}
+
+	return "dated"
+}
+
+// isLiveArticle checks whether the article is a live coverage.
+func (ps *Parser) isLiveArticle(metadata map[string]string, textContent string) bool {
+	if metadata["type"] == "LiveBlogPosting" {
+		return true
+	}
+
+	// The title might already lose its "LIVE:" prefix while cleaned
+	// up, so check the original title as well.
+	if rxLiveDateline.MatchString(ps.articleTitle) {
+		return true
+	}
+
+	if titles := dom.GetElementsByTagName(ps.doc, "title"); len(titles) > 0 &&
+		rxLiveDateline.MatchString(strings.TrimSpace(dom.TextContent(titles[0]))) {
+		return true
+	}
+
+	// Only check the opening line of the content, since those
+	// words are common enough in the middle of the text.
+	openingLine := strings.TrimSpace(textContent)
+	if idx := strings.Index(openingLine, "\n"); idx >= 0 {
+		openingLine = openingLine[:idx]
+	}
+
+	if rxLiveDateline.MatchString(openingLine) {
+		return true
+	}
+
+	return ps.hasLiveBlogClass() || rxLiveBlog.MatchString(ps.topCandidateClass)
+}
+
+// hasLiveBlogClass checks whether the page containers, i.e. <html>,
+// <body>, <main> and <article>, are marked by live blog class names.
+// The other elements are not checked, since a site-wide link or ticker
+// of live updates might be anywhere around the article.
+func (ps *Parser) hasLiveBlogClass() bool {
+	for _, node := range ps.getAllNodesWithTag(ps.doc, "html", "body", "main", "article") {
+		if rxLiveBlog.MatchString(dom.ClassName(node) + " " + dom.ID(node)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package readability
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_getArticleFreshness(t *testing.T) {
+
+	scenarios := map[string]string{
+		"<head><meta property=\"article:published_time\" content=\"2020-03-09T10:00:00Z\"></head>" +
+			"<body><article>" + testParagraph + "</article></body>": "recent",
+		"<head><meta property=\"article:published_time\" content=\"2020-01-01T10:00:00Z\"></head>" +
+			"<body><article>" + testParagraph + "</article></body>": "dated",
+		"<head><title>LIVE: Election results as they come in</title></head>" +
+			"<body><article>" + testParagraph + "</article></body>": "live",
+		"<body><div class=\"liveblog\"><article>" + testParagraph + "</article></div></body>":                                       "live",
+		"<body><article>" + testParagraph + "</article></body>":                                                                     "evergreen",
+		"<body><nav><a class=\"live-updates\" href=\"/live\">Live updates</a></nav><article>" + testParagraph + "</article></body>": "evergreen",
+		"<body><main id=\"live-coverage\"><article>" + testParagraph + "</article></main></body>":                                   "live",
+	}
+
+	parser := NewParser()
+	parser.Now = func() time.Time {
+		return time.Date(2020, 3, 10, 0, 0, 0, 0, time.UTC)
+	}
+
+	for rawHTML, expected := range scenarios {
+		article := parseHTMLString(t, parser, "<html>"+rawHTML+"</html>")
+		if article.Freshness != expected {
+			t.Errorf("\n"+
+				"html : %s\n"+
+				"want : %s\n"+
+				"got  : %s", rawHTML, expected, article.Freshness)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package readability
+
+import (
+	"strconv"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// normalizeHeadingLevels rewrites the headings in article content so
+// their levels are contiguous, while keeping their relative nesting. The
+// top level stays as the highest heading used in content, then each
+// heading is placed one level below the nearest preceding heading which
+// originally has a higher level. For example h2, h4, h4, h3, h6 becomes
+// h2, h3, h3, h3, h4.
+func (ps *Parser) normalizeHeadingLevels(articleContent *html.Node) {
+	var headings []*html.Node
+	var levels []int
+	baseLevel := 6
+
+	for _, node := range dom.GetElementsByTagName(articleContent, "*") {
+		if level := headingLevel(node); level > 0 {
+			headings = append(headings, node)
+			levels = append(levels, level)
+			if level < baseLevel {
+				baseLevel = level
+			}
+		}
+	}
+
+	// Stack of the original levels of the enclosing headings.
+	var stack []int
+	for i, heading := range headings {
+		for len(stack) > 0 && stack[len(stack)-1] >= levels[i] {
+			stack = stack[:len(stack)-1]
+		}
+
+		newLevel := baseLevel + len(stack)
+		if newLevel > 6 {
+			newLevel = 6
+		}
+
+		ps.setNodeTag(heading, "h"+strconv.Itoa(newLevel))
+		stack = append(stack, levels[i])
+	}
+}
+
+// headingLevel returns the level of heading element, or zero if node
+// isn't a heading.
+func headingLevel(node *html.Node) int {
+	tagName := dom.TagName(node)
+	if len(tagName) != 2 || tagName[0] != 'h' || tagName[1] < '1' || tagName[1] > '6' {
+		return 0
+	}
+	return int(tagName[1] - '0')
+}
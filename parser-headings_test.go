@@ -0,0 +1,48 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-shiori/dom"
+)
+
+func Test_normalizeHeadingLevels(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat(testSentence, 6) + "</p>"
+	rawHTML := `<html><body><article>` + paragraph +
+		`<h2>Intro</h2>` + paragraph +
+		`<h4>Detail one</h4>` + paragraph +
+		`<h4>Detail two</h4>` + paragraph +
+		`<h3>Sub section</h3>` + paragraph +
+		`<h6>Deep note</h6>` + paragraph +
+		`<h2>Outro</h2>` + paragraph +
+		`<h5>Final note</h5>` + paragraph +
+		`</article></body></html>`
+
+	getLevels := func(article Article) []string {
+		var tags []string
+		for _, node := range dom.GetElementsByTagName(article.Node, "*") {
+			if headingLevel(node) > 0 {
+				tags = append(tags, dom.TagName(node))
+			}
+		}
+		return tags
+	}
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	expected := []string{"h2", "h4", "h4", "h3", "h6", "h2", "h5"}
+	if levels := getLevels(article); !reflect.DeepEqual(levels, expected) {
+		t.Errorf("\nwant headings unchanged by default %v, got %v", expected, levels)
+	}
+
+	parser.NormalizeHeadingLevels = true
+	article = parseHTMLString(t, parser, rawHTML)
+	expected = []string{"h2", "h3", "h3", "h3", "h4", "h2", "h3"}
+	if levels := getLevels(article); !reflect.DeepEqual(levels, expected) {
+		t.Errorf("\n"+
+			"want : %v\n"+
+			"got  : %v", expected, levels)
+	}
+}
@@ -0,0 +1,98 @@
+package readability
+
+import (
+	nurl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// inferImageDimensions sets the width and height of images in article
+// content which don't declare them. To be conservative, only these
+// patterns are recognized:
+//
+//   - Size in path or file name, e.g. "/img/800x600/foo.jpg" or
+//     "foo-800x600.jpg" as generated by WordPress.
+//   - Cloudinary transformation, e.g. "/upload/w_800,h_600/foo.jpg".
+//   - Both width and height in query, e.g. "?w=800&h=600" or
+//     "?width=800&height=600".
+//   - Width descriptor in srcset of the candidate whose URL is the same
+//     as src, e.g. "foo.jpg 800w", which only gives the width.
+//
+// Images which already declare either width or height are skipped.
+func (ps *Parser) inferImageDimensions(articleContent *html.Node) {
+	for _, img := range dom.GetElementsByTagName(articleContent, "img") {
+		// Mixing declared and inferred size might give wrong ratio.
+		if dom.HasAttribute(img, "width") || dom.HasAttribute(img, "height") {
+			continue
+		}
+
+		src := strings.TrimSpace(dom.GetAttribute(img, "src"))
+		if src == "" {
+			continue
+		}
+
+		width, height := getImageURLDimensions(src)
+		if width == 0 {
+			width = getSrcsetWidth(dom.GetAttribute(img, "srcset"), src)
+		}
+
+		if width > 0 {
+			dom.SetAttribute(img, "width", strconv.Itoa(width))
+		}
+
+		if height > 0 {
+			dom.SetAttribute(img, "height", strconv.Itoa(height))
+		}
+	}
+}
+
+// getImageURLDimensions returns the width and height which encoded in
+// the image URL. Returns zeros if there are none.
+func getImageURLDimensions(src string) (int, int) {
+	parsedURL, err := nurl.Parse(src)
+	if err != nil {
+		return 0, 0
+	}
+
+	query := parsedURL.Query()
+	for _, keys := range [][2]string{{"w", "h"}, {"width", "height"}} {
+		width, errWidth := strconv.Atoi(query.Get(keys[0]))
+		height, errHeight := strconv.Atoi(query.Get(keys[1]))
+		if errWidth == nil && errHeight == nil && width > 0 && height > 0 {
+			return width, height
+		}
+	}
+
+	for _, rx := range []*regexp.Regexp{rxImageCloudinarySize, rxImagePathSize} {
+		if parts := rx.FindStringSubmatch(parsedURL.Path); parts != nil {
+			width, _ := strconv.Atoi(parts[1])
+			height, _ := strconv.Atoi(parts[2])
+			if width > 0 && height > 0 {
+				return width, height
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+// getSrcsetWidth returns the width descriptor of srcset candidate whose
+// URL is src. Returns zero if there are none.
+func getSrcsetWidth(srcset string, src string) int {
+	for _, parts := range rxSrcsetURL.FindAllStringSubmatch(srcset, -1) {
+		descriptor := strings.ToLower(strings.TrimSpace(parts[2]))
+		if strings.TrimSuffix(parts[1], ",") != src || !strings.HasSuffix(descriptor, "w") {
+			continue
+		}
+
+		if width, err := strconv.Atoi(strings.TrimSuffix(descriptor, "w")); err == nil && width > 0 {
+			return width
+		}
+	}
+
+	return 0
+}
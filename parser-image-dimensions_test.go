@@ -0,0 +1,66 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_getImageURLDimensions(t *testing.T) {
+	scenarios := []struct {
+		src    string
+		width  int
+		height int
+	}{
+		{"http://fakehost/img/800x600/foo.jpg", 800, 600},
+		{"http://fakehost/uploads/2020/01/foo-1024x768.jpg", 1024, 768},
+		{"https://res.cloudinary.com/demo/image/upload/w_640,h_480,c_fill/foo.jpg", 640, 480},
+		{"http://fakehost/foo.jpg?w=320&h=240&fit=crop", 320, 240},
+		{"http://fakehost/foo.jpg?width=320&height=240", 320, 240},
+		{"http://fakehost/foo.jpg?w=320", 0, 0},
+		{"http://fakehost/foo@2x.jpg", 0, 0},
+		{"http://fakehost/2020x/foo.jpg", 0, 0},
+	}
+
+	for _, scenario := range scenarios {
+		width, height := getImageURLDimensions(scenario.src)
+		if width != scenario.width || height != scenario.height {
+			t.Errorf("\n"+
+				"src  : %s\n"+
+				"want : %dx%d\n"+
+				"got  : %dx%d", scenario.src, scenario.width, scenario.height, width, height)
+		}
+	}
+}
+
+func Test_getSrcsetWidth(t *testing.T) {
+	srcset := "http://fakehost/small.jpg 480w, http://fakehost/large.jpg 1200w"
+	if width := getSrcsetWidth(srcset, "http://fakehost/large.jpg"); width != 1200 {
+		t.Errorf("\nwant 1200, got %d", width)
+	}
+
+	if width := getSrcsetWidth(srcset, "http://fakehost/other.jpg"); width != 0 {
+		t.Errorf("\nwant 0 for src not in srcset, got %d", width)
+	}
+}
+
+func Test_inferImageDimensions(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p><img src="/img/800x600/foo.jpg"></p>` +
+		`<p><img src="/img/800x600/bar.jpg" width="400"></p>` +
+		testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.InferImageDimensions = true
+	article := parseHTMLString(t, parser, rawHTML)
+
+	expected := []string{
+		`<img src="http://fakehost/img/800x600/foo.jpg" width="800" height="600"/>`,
+		`<img src="http://fakehost/img/800x600/bar.jpg" width="400"/>`,
+	}
+
+	for _, img := range expected {
+		if !strings.Contains(article.Content, img) {
+			t.Errorf("\nwant %s, got %s", img, article.Content)
+		}
+	}
+}
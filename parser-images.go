@@ -0,0 +1,114 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// ImageInfo is an image in the article content.
+type ImageInfo struct {
+	// URL is the absolute URL of the image.
+	URL string
+	// Alt is the alternative text of the image.
+	Alt string
+	// Caption is the caption of the image in <figcaption>, without its
+	// credit.
+	Caption string
+	// Credit is the credit of the image (e.g. "Getty Images"), taken from
+	// the credit element in caption, the credit pattern in caption text
+	// (e.g. "Photo: Getty Images"), data-credit attribute, or creditText
+	// of ImageObject in JSON-LD. Empty if there are none.
+	Credit string
+}
+
+// getImages collects the images in article content along with their
+// caption and credit. The credit elements are recognized by their class,
+// so this must be run before the classes are removed.
+func (ps *Parser) getImages(articleContent *html.Node, metadata map[string]string) []ImageInfo {
+	var images []ImageInfo
+	for _, img := range dom.GetElementsByTagName(articleContent, "img") {
+		src := strings.TrimSpace(dom.GetAttribute(img, "src"))
+		if src == "" || isUnsafeURL(src) {
+			continue
+		}
+
+		image := ImageInfo{
+			URL: toAbsoluteURI(src, ps.documentURI),
+			Alt: strings.TrimSpace(dom.GetAttribute(img, "alt")),
+		}
+
+		image.Credit = ps.getDataCredit(img)
+
+		var figure *html.Node
+		for parent := img.Parent; parent != nil && parent != articleContent; parent = parent.Parent {
+			if dom.TagName(parent) == "figure" {
+				figure = parent
+				break
+			}
+		}
+
+		if figure != nil {
+			if image.Credit == "" {
+				image.Credit = ps.getDataCredit(figure)
+			}
+
+			if captions := dom.GetElementsByTagName(figure, "figcaption"); len(captions) > 0 {
+				caption, credit := ps.splitImageCredit(captions[0])
+				image.Caption = caption
+				image.Credit = strOr(image.Credit, credit)
+			}
+		}
+
+		if image.Credit == "" && metadata["imageCredit"] != "" && image.URL == metadata["imageCreditURL"] {
+			image.Credit = metadata["imageCredit"]
+		}
+
+		images = append(images, image)
+	}
+
+	return images
+}
+
+// getDataCredit returns the credit declared in data attribute of node.
+func (ps *Parser) getDataCredit(node *html.Node) string {
+	for _, attrName := range []string{"data-credit", "data-photo-credit", "data-image-credit", "data-copyright"} {
+		if credit := strings.TrimSpace(dom.GetAttribute(node, attrName)); credit != "" {
+			return credit
+		}
+	}
+	return ""
+}
+
+// splitImageCredit splits the text of figcaption into the caption and
+// the credit. The credit is taken from element whose class says it's
+// credit, or from the end of caption which matches the credit pattern.
+func (ps *Parser) splitImageCredit(figcaption *html.Node) (string, string) {
+	caption := ps.getInnerText(figcaption, true)
+
+	for _, node := range dom.GetElementsByTagName(figcaption, "*") {
+		if !rxImageCreditClass.MatchString(dom.ClassName(node)) {
+			continue
+		}
+
+		creditText := ps.getInnerText(node, true)
+		if creditText == "" {
+			continue
+		}
+
+		caption = strings.TrimSpace(strings.Replace(caption, creditText, "", 1))
+		if parts := rxImageCreditText.FindStringSubmatch(creditText); parts != nil {
+			creditText = parts[1]
+		}
+		return caption, strings.TrimSpace(creditText)
+	}
+
+	if loc := rxImageCreditText.FindStringSubmatchIndex(caption); loc != nil {
+		credit := strings.TrimSpace(caption[loc[2]:loc[3]])
+		caption = strings.TrimSpace(strings.TrimRight(caption[:loc[0]], " ([|"))
+		return caption, credit
+	}
+
+	return caption, ""
+}
@@ -0,0 +1,63 @@
+package readability
+
+import (
+	"testing"
+)
+
+func Test_getImages(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		head    string
+		figure  string
+		caption string
+		credit  string
+	}{
+		{"no credit", ``,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn.</figcaption></figure>`,
+			"The harbor at dawn.", ""},
+		{"credit element", ``,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn. <span class="credit">Jane Doe/Reuters</span></figcaption></figure>`,
+			"The harbor at dawn.", "Jane Doe/Reuters"},
+		{"credit element with label", ``,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn. <small class="photo-credit">Photo: Jane Doe</small></figcaption></figure>`,
+			"The harbor at dawn.", "Jane Doe"},
+		{"credit text", ``,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn. (Photo by Jane Doe)</figcaption></figure>`,
+			"The harbor at dawn.", "Jane Doe"},
+		{"copyright text", ``,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn. © Getty Images</figcaption></figure>`,
+			"The harbor at dawn.", "Getty Images"},
+		{"data attribute", ``,
+			`<figure data-credit="AP Photo"><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn.</figcaption></figure>`,
+			"The harbor at dawn.", "AP Photo"},
+		{"json-ld credit", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+			`"image":{"@type":"ImageObject","url":"http://fakehost/test/photo.jpg","creditText":"Acme Photos"}}</script>`,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn.</figcaption></figure>`,
+			"The harbor at dawn.", "Acme Photos"},
+		{"json-ld credit of other image", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+			`"image":[{"@type":"ImageObject","url":"http://fakehost/test/other.jpg","creditText":"Acme Photos"}]}</script>`,
+			`<figure><img src="photo.jpg" alt="Photo"><figcaption>The harbor at dawn.</figcaption></figure>`,
+			"The harbor at dawn.", ""},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head>` + scenario.head + `</head><body><article><h1>Title</h1>` +
+			testParagraph + scenario.figure + testParagraph + `</article></body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if len(article.Images) != 1 {
+			t.Errorf("\n%s: want 1 image, got %d", scenario.name, len(article.Images))
+			continue
+		}
+
+		image := article.Images[0]
+		if image.URL != "http://fakehost/test/photo.jpg" {
+			t.Errorf("\n%s: want URL %q, got %q", scenario.name, "http://fakehost/test/photo.jpg", image.URL)
+		}
+		if image.Caption != scenario.caption {
+			t.Errorf("\n%s: want caption %q, got %q", scenario.name, scenario.caption, image.Caption)
+		}
+		if image.Credit != scenario.credit {
+			t.Errorf("\n%s: want credit %q, got %q", scenario.name, scenario.credit, image.Credit)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package readability
+
+import "encoding/json"
+
+// MarshalJSON encodes the article into JSON, which contains every field
+// except Node, since the DOM node has cycles (e.g. between parent and
+// child) and can't be encoded. Use Content for the HTML of article. The
+// time fields are encoded in RFC 3339, or null if they're not found.
+func (article Article) MarshalJSON() ([]byte, error) {
+	// Use a type without methods, so it doesn't call MarshalJSON again.
+	// Its Node is hidden by the outer field which is always omitted,
+	// since field tagged with "-" doesn't hide the embedded one.
+	type plainArticle Article
+	return json.Marshal(struct {
+		plainArticle
+		Node *struct{} `json:",omitempty"`
+	}{plainArticle: plainArticle(article)})
+}
@@ -0,0 +1,59 @@
+package readability
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test_articleMarshalJSON(t *testing.T) {
+	rawHTML := `<html><head><title>JSON title</title>` +
+		`<meta property="article:published_time" content="2021-03-04T10:00:00Z">` +
+		`</head><body><article><p class="byline">By Jane Doe</p>` + testParagraph + testParagraph +
+		`</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if article.Node == nil {
+		t.Fatalf("\nwant article with node")
+	}
+
+	encoded, err := json.Marshal(article)
+	if err != nil {
+		t.Fatalf("\nfailed to marshal article: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		t.Fatalf("\nfailed to unmarshal fields: %v", err)
+	}
+
+	if _, exist := fields["Node"]; exist {
+		t.Errorf("\nwant Node omitted, got %s", encoded)
+	}
+
+	if fields["PublishedTime"] != "2021-03-04T10:00:00Z" {
+		t.Errorf("\nwant published time in RFC 3339, got %v", fields["PublishedTime"])
+	}
+
+	if value, exist := fields["ModifiedTime"]; !exist || value != nil {
+		t.Errorf("\nwant null modified time, got %v", value)
+	}
+
+	var decoded Article
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("\nfailed to unmarshal article: %v", err)
+	}
+
+	if decoded.Title != article.Title || decoded.Byline != article.Byline ||
+		decoded.Content != article.Content || decoded.TextContent != article.TextContent ||
+		decoded.Length != article.Length || decoded.ReadingTime != article.ReadingTime {
+		t.Errorf("\nwant the same article after round trip, got %+v", decoded)
+	}
+
+	if decoded.PublishedTime == nil || !decoded.PublishedTime.Equal(*article.PublishedTime) {
+		t.Errorf("\nwant published time %v, got %v", article.PublishedTime, decoded.PublishedTime)
+	}
+
+	if decoded.Node != nil {
+		t.Errorf("\nwant no node after round trip")
+	}
+}
@@ -0,0 +1,44 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+)
+
+// getDocumentLanguage returns the language declared in the document,
+// as it's written there (e.g. "en-US" or "fr_FR"). It's taken from lang
+// attribute of <html>, then og:locale meta, then Content-Language meta.
+func (ps *Parser) getDocumentLanguage() string {
+	if htmlElements := dom.GetElementsByTagName(ps.doc, "html"); len(htmlElements) > 0 {
+		if lang := strings.TrimSpace(dom.GetAttribute(htmlElements[0], "lang")); lang != "" {
+			return lang
+		}
+	}
+
+	var ogLocale, contentLanguage string
+	for _, meta := range dom.GetElementsByTagName(ps.doc, "meta") {
+		content := strings.TrimSpace(dom.GetAttribute(meta, "content"))
+		switch {
+		case content == "":
+			continue
+		case ogLocale == "" && strings.EqualFold(dom.GetAttribute(meta, "property"), "og:locale"):
+			ogLocale = content
+		case contentLanguage == "" && strings.EqualFold(dom.GetAttribute(meta, "http-equiv"), "content-language"):
+			// The header might list several languages, use the first one.
+			contentLanguage = strings.TrimSpace(strings.Split(content, ",")[0])
+		}
+	}
+
+	return strOr(ogLocale, contentLanguage)
+}
+
+// baseLanguage returns the primary subtag of language tag, lowercased,
+// e.g. "en" for "en-US" and "pt" for "pt_BR".
+func baseLanguage(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if idx := strings.IndexAny(lang, "-_"); idx >= 0 {
+		lang = lang[:idx]
+	}
+	return strings.ToLower(lang)
+}
@@ -0,0 +1,44 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_getDocumentLanguage(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		htmlAttr    string
+		head        string
+		language    string
+		languageTag string
+	}{
+		{"html lang", ` lang="fr"`, ``, "fr", "fr"},
+		{"html lang with region", ` lang="en-US"`, `<meta property="og:locale" content="de_DE">`, "en", "en-US"},
+		{"og:locale", ``, `<meta property="og:locale" content="pt_BR">`, "pt", "pt_BR"},
+		{"content-language", ``, `<meta http-equiv="Content-Language" content="es-MX, en">`, "es", "es-MX"},
+		{"undeclared", ``, ``, "", ""},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html` + scenario.htmlAttr + `><head><title>Title</title>` + scenario.head + `</head>` +
+			`<body><article><h1>Title</h1>` + testParagraph + testParagraph + `</article></body></html>`
+
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Language != scenario.language || article.LanguageTag != scenario.languageTag {
+			t.Errorf("\n%s: want %q (%q), got %q (%q)", scenario.name,
+				scenario.language, scenario.languageTag, article.Language, article.LanguageTag)
+		}
+
+		parser := NewParser()
+		article, err := parser.ParseMetadataFast(strings.NewReader(rawHTML), nil)
+		if err != nil {
+			t.Fatalf("\n%s: failed to parse metadata: %v", scenario.name, err)
+		}
+
+		if article.Language != scenario.language || article.LanguageTag != scenario.languageTag {
+			t.Errorf("\n%s: fast metadata want %q (%q), got %q (%q)", scenario.name,
+				scenario.language, scenario.languageTag, article.Language, article.LanguageTag)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package readability
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// LiveEntry is a timestamped entry of a live blog.
+type LiveEntry struct {
+	// Time is when the entry is posted. Nil if it can't be parsed.
+	Time *time.Time
+	// HTML is the outer HTML of the entry, with absolute URLs.
+	HTML string
+	// Text is the text content of the entry.
+	Text string
+}
+
+// getLiveEntries returns the entries of live blog, in the order they
+// appear in the page. The page is considered a live blog if it's declared
+// as LiveBlogPosting in JSON-LD or its containers are marked by live blog
+// class names. The entries are the largest group of sibling elements
+// inside <article> or <main> (or <body> if there are none) which each
+// have a timestamp in <time>, so the timestamped lists around the article
+// like related articles are skipped. Since grabArticle merges and cleans
+// up the entries, this must be run before it. Returns nil for normal
+// articles.
+func (ps *Parser) getLiveEntries(metadata map[string]string) []LiveEntry {
+	if metadata["type"] != "LiveBlogPosting" && !ps.hasLiveBlogClass() {
+		return nil
+	}
+
+	containers := ps.getAllNodesWithTag(ps.doc, "article", "main")
+	if len(containers) == 0 {
+		containers = ps.getAllNodesWithTag(ps.doc, "body")
+	}
+
+	var bestEntries []*html.Node
+	for _, container := range containers {
+		parents := append([]*html.Node{container}, dom.GetElementsByTagName(container, "*")...)
+		for _, parent := range parents {
+			var entries []*html.Node
+			for _, child := range dom.Children(parent) {
+				if len(dom.GetElementsByTagName(child, "time")) > 0 &&
+					ps.isProbablyVisible(child) {
+					entries = append(entries, child)
+				}
+			}
+
+			if len(entries) > len(bestEntries) {
+				bestEntries = entries
+			}
+		}
+	}
+
+	if len(bestEntries) < 2 {
+		return nil
+	}
+
+	liveEntries := make([]LiveEntry, len(bestEntries))
+	for i, entry := range bestEntries {
+		timeElement := dom.GetElementsByTagName(entry, "time")[0]
+		strTime := strings.TrimSpace(dom.GetAttribute(timeElement, "datetime"))
+		if strTime == "" {
+			strTime = strings.TrimSpace(dom.TextContent(timeElement))
+		}
+
+		clone := dom.Clone(entry, true)
+		ps.fixRelativeURIs(clone)
+
+		liveEntries[i] = LiveEntry{
+			Time: ps.parseDate(strTime),
+			HTML: dom.OuterHTML(clone),
+			Text: ps.getInnerText(entry, true),
+		}
+	}
+
+	return liveEntries
+}
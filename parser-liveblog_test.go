@@ -0,0 +1,78 @@
+package readability
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_getLiveEntries(t *testing.T) {
+	entry := func(datetime, text string) string {
+		return `<div class="entry"><time datetime="` + datetime + `">` + datetime + `</time>` +
+			`<p>` + strings.Repeat(text+" ", 8) + `<a href="/photo">photo</a></p></div>`
+	}
+
+	entries := entry("2021-03-04T12:30:00Z", "Third update from the scene.") +
+		entry("2021-03-04T12:00:00Z", "Second update from the scene.") +
+		entry("2021-03-04T11:30:00Z", "First update from the scene.")
+
+	parser := NewParser()
+	parser.DetectLiveBlog = true
+
+	// Normal article is left empty.
+	rawHTML := `<html><body><article>` + entries + `</article></body></html>`
+	if article := parseHTMLString(t, parser, rawHTML); article.LiveEntries != nil {
+		t.Errorf("\nwant no entries for normal article, got %d", len(article.LiveEntries))
+	}
+
+	rawHTML = `<html><body><article class="live-blog">` + entries + `</article></body></html>`
+	article := parseHTMLString(t, parser, rawHTML)
+	if len(article.LiveEntries) != 3 {
+		t.Fatalf("\nwant 3 entries, got %d", len(article.LiveEntries))
+	}
+
+	expectedTimes := []string{"2021-03-04T12:30:00Z", "2021-03-04T12:00:00Z", "2021-03-04T11:30:00Z"}
+	for i, liveEntry := range article.LiveEntries {
+		if liveEntry.Time == nil || liveEntry.Time.Format(time.RFC3339) != expectedTimes[i] {
+			t.Errorf("\nwant entry time %s, got %v", expectedTimes[i], liveEntry.Time)
+		}
+
+		if !strings.Contains(liveEntry.HTML, `href="http://fakehost/photo"`) {
+			t.Errorf("\nwant absolute URLs in entry, got %s", liveEntry.HTML)
+		}
+	}
+
+	if !strings.Contains(article.LiveEntries[0].Text, "Third update") {
+		t.Errorf("\nwant entries in page order, got %q", article.LiveEntries[0].Text)
+	}
+
+	if !strings.Contains(article.TextContent, "First update") {
+		t.Errorf("\nwant merged content to be kept, got %.80q", article.TextContent)
+	}
+
+	// Link to live updates elsewhere doesn't make the page a live blog.
+	rawHTML = `<html><body><nav><a class="live-updates" href="/live">Live updates</a></nav>` +
+		`<article>` + entries + `</article></body></html>`
+	if article := parseHTMLString(t, parser, rawHTML); article.LiveEntries != nil {
+		t.Errorf("\nwant no entries for live updates link, got %d", len(article.LiveEntries))
+	}
+
+	// Timestamped list outside the article is never used as entries.
+	related := `<aside><ul>`
+	for i := 1; i <= 5; i++ {
+		related += `<li><time datetime="2021-03-0` + strconv.Itoa(i) + `">March ` + strconv.Itoa(i) + `</time>` +
+			`<a href="/related">Related story</a></li>`
+	}
+	related += `</ul></aside>`
+
+	rawHTML = `<html><body><article class="live-blog">` + entries + `</article>` + related + `</body></html>`
+	article = parseHTMLString(t, parser, rawHTML)
+	if len(article.LiveEntries) != 3 {
+		t.Fatalf("\nwant 3 entries from the article, got %d", len(article.LiveEntries))
+	}
+
+	if !strings.Contains(article.LiveEntries[0].Text, "Third update") {
+		t.Errorf("\nwant entries from the article, got %q", article.LiveEntries[0].Text)
+	}
+}
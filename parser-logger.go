@@ -0,0 +1,16 @@
+package readability
+
+// Logger is used by Parser to report the problems which don't stop the
+// parsing, e.g. the date that can't be parsed. It's satisfied by
+// *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// logf writes the message to Parser.Logger. Nothing is written if the
+// logger is not set.
+func (ps *Parser) logf(format string, v ...interface{}) {
+	if ps.Logger != nil {
+		ps.Logger.Printf(format, v...)
+	}
+}
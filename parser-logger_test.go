@@ -0,0 +1,43 @@
+package readability
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_Logger(t *testing.T) {
+	rawHTML := articleHTML(`<meta property="article:published_time" content="sometime last week">`, `<h1>Title</h1>`)
+
+	// Capture stdout, to make sure nothing is printed there.
+	stdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	os.Stdout = writer
+
+	var logs bytes.Buffer
+	parser := NewParser()
+	parser.Logger = log.New(&logs, "", 0)
+	article := parseHTMLString(t, parser, rawHTML)
+
+	os.Stdout = stdout
+	writer.Close()
+	printed, _ := ioutil.ReadAll(reader)
+
+	if article.PublishedTime != nil {
+		t.Errorf("want nil published time, got %v", article.PublishedTime)
+	}
+
+	if len(printed) != 0 {
+		t.Errorf("want nothing printed to stdout, got %q", printed)
+	}
+
+	if want := `failed to parse date "sometime last week"`; !strings.Contains(logs.String(), want) {
+		t.Errorf("want log containing %q, got %q", want, logs.String())
+	}
+}
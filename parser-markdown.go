@@ -0,0 +1,369 @@
+package readability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// markdownEscaper escapes the chars which have special meaning in
+// Markdown text.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+
+// Markdown renders the article content as Markdown. It walks the already
+// cleaned Node, so the content is not parsed again. Headings, paragraphs,
+// lists, links, images, block quotes, code blocks, tables and emphasis
+// are converted into their Markdown form, while the other elements are
+// rendered by their content. Returns ErrNoContent if the article has no
+// content.
+func (article Article) Markdown() (string, error) {
+	if article.Node == nil {
+		return "", ErrNoContent
+	}
+
+	blocks := markdownBlocks(article.Node)
+	if len(blocks) == 0 {
+		return "", nil
+	}
+	return strings.Join(blocks, "\n\n") + "\n", nil
+}
+
+// markdownBlocks renders the children of node as Markdown blocks. The
+// consecutive inline children are joined into a paragraph.
+func markdownBlocks(node *html.Node) []string {
+	var blocks []string
+	var inline strings.Builder
+	flushInline := func() {
+		if paragraph := markdownParagraph(inline.String()); paragraph != "" {
+			blocks = append(blocks, paragraph)
+		}
+		inline.Reset()
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if !isMarkdownBlock(child) {
+			inline.WriteString(markdownInline(child))
+			continue
+		}
+
+		flushInline()
+		blocks = append(blocks, markdownBlock(child)...)
+	}
+
+	flushInline()
+	return blocks
+}
+
+// isMarkdownBlock determines if node is rendered as Markdown block.
+func isMarkdownBlock(node *html.Node) bool {
+	if node.Type != html.ElementNode {
+		return false
+	}
+
+	_, isBlock := blockElems[dom.TagName(node)]
+	return isBlock
+}
+
+// markdownBlock renders the block element as Markdown blocks.
+func markdownBlock(node *html.Node) []string {
+	tagName := dom.TagName(node)
+	switch tagName {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		text := markdownParagraph(markdownInlineChildren(node))
+		if text == "" {
+			return nil
+		}
+
+		level, _ := strconv.Atoi(tagName[1:])
+		return []string{strings.Repeat("#", level) + " " + strings.Replace(text, "  \n", " ", -1)}
+
+	case "pre":
+		return []string{markdownCodeBlock(node)}
+
+	case "blockquote":
+		blocks := markdownBlocks(node)
+		if len(blocks) == 0 {
+			return nil
+		}
+
+		lines := strings.Split(strings.Join(blocks, "\n\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return []string{strings.Join(lines, "\n")}
+
+	case "ul", "ol":
+		if list := markdownList(node); list != "" {
+			return []string{list}
+		}
+		return nil
+
+	case "table":
+		if table := markdownTable(node); table != "" {
+			return []string{table}
+		}
+		return nil
+
+	case "hr":
+		return []string{"---"}
+
+	case "dt":
+		if term := markdownParagraph(markdownInlineChildren(node)); term != "" {
+			return []string{"**" + term + "**"}
+		}
+		return nil
+
+	default:
+		return markdownBlocks(node)
+	}
+}
+
+// markdownParagraph cleans up the inline Markdown into a paragraph: the
+// whitespaces in lines are collapsed, except the hard line breaks.
+func markdownParagraph(inline string) string {
+	lines := strings.Split(strings.TrimSpace(inline), "\n")
+	var result []string
+	for i, line := range lines {
+		isHardBreak := i < len(lines)-1 && strings.HasSuffix(line, "  ")
+		line = strings.Join(strings.Fields(line), " ")
+		if isHardBreak {
+			line += "  "
+		}
+
+		if strings.TrimSpace(line) != "" {
+			result = append(result, line)
+		}
+	}
+
+	if len(result) > 0 {
+		last := len(result) - 1
+		result[last] = strings.TrimRight(result[last], " ")
+	}
+	return strings.Join(result, "\n")
+}
+
+// markdownInlineChildren renders the children of node as inline Markdown.
+func markdownInlineChildren(node *html.Node) string {
+	var buffer strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		buffer.WriteString(markdownInline(child))
+	}
+	return buffer.String()
+}
+
+// markdownInline renders node as inline Markdown.
+func markdownInline(node *html.Node) string {
+	switch node.Type {
+	case html.TextNode:
+		return markdownEscaper.Replace(rxNormalize.ReplaceAllString(strings.Replace(node.Data, "\n", " ", -1), " "))
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch dom.TagName(node) {
+	case "br":
+		return "  \n"
+
+	case "img":
+		src := dom.GetAttribute(node, "src")
+		if src == "" {
+			return ""
+		}
+
+		alt := markdownEscaper.Replace(strings.TrimSpace(dom.GetAttribute(node, "alt")))
+		return "![" + alt + "](" + markdownURL(src) + ")"
+
+	case "a":
+		text := markdownInlineChildren(node)
+		href := dom.GetAttribute(node, "href")
+		if href == "" || strings.HasPrefix(href, "#") || strings.TrimSpace(text) == "" {
+			return text
+		}
+		return wrapMarkdownInline("[", strings.Replace(text, "  \n", " ", -1), "]("+markdownURL(href)+")")
+
+	case "strong", "b":
+		return wrapMarkdownInline("**", markdownInlineChildren(node), "**")
+
+	case "em", "i", "cite":
+		return wrapMarkdownInline("*", markdownInlineChildren(node), "*")
+
+	case "del", "s", "strike":
+		return wrapMarkdownInline("~~", markdownInlineChildren(node), "~~")
+
+	case "code", "kbd", "samp", "tt":
+		code := strings.Join(strings.Fields(dom.TextContent(node)), " ")
+		if code == "" {
+			return ""
+		}
+
+		fence := "`"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+
+		if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+			code = " " + code + " "
+		}
+		return fence + code + fence
+
+	default:
+		return markdownInlineChildren(node)
+	}
+}
+
+// wrapMarkdownInline wraps the inline text with prefix and suffix, while
+// keeping its surrounding spaces outside, since Markdown doesn't allow
+// spaces right inside the emphasis markers.
+func wrapMarkdownInline(prefix, text, suffix string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+	return leading + prefix + trimmed + suffix + trailing
+}
+
+// markdownURL escapes the URL to be used as link destination.
+func markdownURL(url string) string {
+	url = strings.TrimSpace(url)
+	url = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(url)
+	return url
+}
+
+// markdownCodeBlock renders <pre> as fenced code block, along with its
+// language if it's declared in the class of <pre> or its <code>.
+func markdownCodeBlock(pre *html.Node) string {
+	code := strings.TrimRight(dom.TextContent(pre), "\n")
+	code = strings.TrimLeft(code, "\n")
+
+	var language string
+	nodes := append([]*html.Node{pre}, dom.GetElementsByTagName(pre, "code")...)
+	for _, node := range nodes {
+		for _, class := range strings.Fields(dom.ClassName(node)) {
+			if parts := rxCodeLanguageClass.FindStringSubmatch(class); parts != nil && language == "" {
+				language = strings.ToLower(parts[1])
+			}
+		}
+	}
+
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	return fence + language + "\n" + code + "\n" + fence
+}
+
+// markdownList renders <ul> and <ol> as Markdown list. The content of
+// item is indented under its marker, so nested lists and multiple
+// paragraphs stay inside the item.
+func markdownList(list *html.Node) string {
+	ordered := dom.TagName(list) == "ol"
+	number := 1
+	if start, err := strconv.Atoi(dom.GetAttribute(list, "start")); ordered && err == nil {
+		number = start
+	}
+
+	var items []string
+	for _, item := range dom.Children(list) {
+		if dom.TagName(item) != "li" {
+			continue
+		}
+
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", number)
+			number++
+		}
+
+		content := strings.Join(markdownBlocks(item), "\n")
+		lines := strings.Split(content, "\n")
+		indent := strings.Repeat(" ", len(marker))
+		for i, line := range lines {
+			switch {
+			case i == 0:
+				lines[i] = strings.TrimRight(marker+line, " ")
+			case line != "":
+				lines[i] = indent + line
+			}
+		}
+
+		items = append(items, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// markdownTable renders <table> as GFM table, using its first row as
+// the header. The cells are rendered as inline text, since Markdown
+// table can't contain blocks.
+func markdownTable(table *html.Node) string {
+	var rows [][]string
+	nColumns := 0
+	for _, row := range dom.GetElementsByTagName(table, "tr") {
+		// Skip the rows of nested table.
+		if closest := closestTable(row); closest != table {
+			continue
+		}
+
+		var cells []string
+		for _, cell := range dom.Children(row) {
+			if tagName := dom.TagName(cell); tagName != "td" && tagName != "th" {
+				continue
+			}
+
+			text := strings.Join(markdownBlocks(cell), " ")
+			text = strings.Replace(text, "  \n", " ", -1)
+			text = strings.Replace(text, "\n", " ", -1)
+			cells = append(cells, strings.Replace(text, "|", `\|`, -1))
+		}
+
+		if len(cells) == 0 {
+			continue
+		}
+
+		if len(cells) > nColumns {
+			nColumns = len(cells)
+		}
+		rows = append(rows, cells)
+	}
+
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var lines []string
+	for i, cells := range rows {
+		for len(cells) < nColumns {
+			cells = append(cells, "")
+		}
+
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if i == 0 {
+			separators := make([]string, nColumns)
+			for j := range separators {
+				separators[j] = "---"
+			}
+			lines = append(lines, "| "+strings.Join(separators, " | ")+" |")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// closestTable returns the nearest <table> ancestor of node.
+func closestTable(node *html.Node) *html.Node {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if dom.TagName(parent) == "table" {
+			return parent
+		}
+	}
+	return nil
+}
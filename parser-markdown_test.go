@@ -0,0 +1,83 @@
+package readability
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	fp "path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+func Test_Markdown(t *testing.T) {
+	testDir := "test-markdown"
+	testItems, err := ioutil.ReadDir(testDir)
+	if err != nil {
+		t.Fatalf("\nfailed to read test directory: %v", err)
+	}
+
+	for _, item := range testItems {
+		if !item.IsDir() {
+			continue
+		}
+
+		t.Run(item.Name(), func(t1 *testing.T) {
+			testFile, err := os.Open(fp.Join(testDir, item.Name(), "source.html"))
+			if err != nil {
+				t1.Fatalf("\nfailed to open test file: %v", err)
+			}
+			defer testFile.Close()
+
+			expected, err := ioutil.ReadFile(fp.Join(testDir, item.Name(), "expected.md"))
+			if err != nil {
+				t1.Fatalf("\nfailed to open expected result file: %v", err)
+			}
+
+			parsedURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+			article, err := FromReader(testFile, parsedURL)
+			if err != nil {
+				t1.Fatalf("\nfailed to parse test file: %v", err)
+			}
+
+			markdown, err := article.Markdown()
+			if err != nil {
+				t1.Fatalf("\nfailed to render markdown: %v", err)
+			}
+
+			if markdown != string(expected) {
+				t1.Errorf("\nwant:\n%s\ngot:\n%s", expected, markdown)
+			}
+		})
+	}
+
+	if _, err := (Article{}).Markdown(); err != ErrNoContent {
+		t.Errorf("\nwant ErrNoContent for empty article, got %v", err)
+	}
+}
+
+func Test_markdownBlocks(t *testing.T) {
+	scenarios := map[string]string{
+		`<p>Use *stars* and _underscores_ literally.</p>`:     `Use \*stars\* and \_underscores\_ literally.`,
+		`<p>First line<br>second line</p>`:                    "First line  \nsecond line",
+		`<p>Run <code>a ` + "`" + `b` + "`" + ` c</code></p>`: "Run ``a `b` c``",
+		`<p><a href="#note">Jump</a> and <b> bold </b></p>`:   "Jump and **bold**",
+		`<ol start="3"><li>Three</li><li>Four</li></ol>`:      "3. Three\n4. Four",
+		`<blockquote><p>One</p><p>Two</p></blockquote>`:       "> One\n>\n> Two",
+		"<pre>```\ncode\n```</pre>":                           "````\n```\ncode\n```\n````",
+	}
+
+	for input, expected := range scenarios {
+		doc, err := html.Parse(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("\nfailed to parse %q: %v", input, err)
+		}
+
+		body := dom.GetElementsByTagName(doc, "body")[0]
+		if got := strings.Join(markdownBlocks(body), "\n\n"); got != expected {
+			t.Errorf("\ninput : %s\nwant  : %q\ngot   : %q", input, expected, got)
+		}
+	}
+}
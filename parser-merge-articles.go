@@ -0,0 +1,49 @@
+package readability
+
+import (
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// mergeSiblingArticles looks for the parent which has the most sibling
+// <article> elements with substantial content (at least CharThresholds
+// chars), and merges them into a single <article>. Each entry becomes a
+// <section>, separated by <hr>, so the order and boundaries between the
+// entries are preserved. Nothing is changed if there are less than two
+// substantial sibling articles.
+func (ps *Parser) mergeSiblingArticles() {
+	var bestEntries []*html.Node
+	for _, parent := range ps.getAllNodesWithTag(ps.doc, "body", "main", "section", "div") {
+		var entries []*html.Node
+		for _, child := range dom.Children(parent) {
+			if dom.TagName(child) == "article" && ps.isSubstantialArticle(child) {
+				entries = append(entries, child)
+			}
+		}
+
+		if len(entries) > len(bestEntries) {
+			bestEntries = entries
+		}
+	}
+
+	if len(bestEntries) < 2 {
+		return
+	}
+
+	merged := dom.CreateElement("article")
+	bestEntries[0].Parent.InsertBefore(merged, bestEntries[0])
+	for i, entry := range bestEntries {
+		if i > 0 {
+			dom.AppendChild(merged, dom.CreateElement("hr"))
+		}
+
+		ps.setNodeTag(entry, "section")
+		dom.AppendChild(merged, entry)
+	}
+}
+
+// isSubstantialArticle determines if the article has enough text to be
+// considered as content of its own, instead of a teaser.
+func (ps *Parser) isSubstantialArticle(article *html.Node) bool {
+	return charCount(ps.getInnerText(article, true)) >= ps.CharThresholds
+}
@@ -0,0 +1,40 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_mergeSiblingArticles(t *testing.T) {
+	entry := func(word string) string {
+		return `<article><h2>Entry ` + word + `</h2><p>` +
+			strings.Repeat("The "+word+" entry of the newsletter talks about many things, at length. ", 10) +
+			`</p></article>`
+	}
+
+	rawHTML := `<html><body><main>` + entry("first") + entry("second") + entry("third") + `</main></body></html>`
+
+	parser := NewParser()
+	parser.MergeMultipleArticles = true
+	article := parseHTMLString(t, parser, rawHTML)
+
+	first := strings.Index(article.TextContent, "The first entry")
+	second := strings.Index(article.TextContent, "The second entry")
+	third := strings.Index(article.TextContent, "The third entry")
+	if first == -1 || second < first || third < second {
+		t.Errorf("\nwant all entries in order, got %.200s", article.TextContent)
+	}
+
+	if strings.Count(article.Content, "<hr/>") != 2 {
+		t.Errorf("\nwant entries separated by <hr>, got %s", article.Content)
+	}
+
+	// Teaser articles are not merged.
+	rawHTML = `<html><body><main>` + entry("first") +
+		`<article><p>Short teaser.</p></article><article><p>Another teaser.</p></article>` +
+		`</main></body></html>`
+	article = parseHTMLString(t, parser, rawHTML)
+	if strings.Contains(article.TextContent, "teaser") {
+		t.Errorf("\nwant teasers to be ignored, got %.200s", article.TextContent)
+	}
+}
@@ -0,0 +1,227 @@
+package readability
+
+import (
+	"bytes"
+	"context"
+	"io"
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// ParseMetadataFast extracts only the metadata of the page (e.g. title,
+// excerpt, image and dates) without looking for the readable content,
+// which is enough for link previews. Instead of building DOM of the whole
+// page, the input is scanned using tokenizer until the end of <head>, plus
+// the first <h1> and <p> in body as fallback for title and excerpt. The
+// content fields (Node, Content, TextContent, etc) are left empty.
+//
+// If the page doesn't have any title in its head, it falls back to the
+// full Parse so the metadata is as complete as possible.
+func (ps *Parser) ParseMetadataFast(input io.Reader, pageURL *nurl.URL) (Article, error) {
+	// Keep the scanned bytes, in case we need to fall back to full parse.
+	// They are kept before the charset conversion, since Parse converts
+	// them on its own.
+	var scanned bytes.Buffer
+	var reader io.Reader = io.TeeReader(input, &scanned)
+	if ps.DetectCharset {
+		reader = decodeCharsetStream(reader)
+	}
+
+	doc := ps.scanMetadataDocument(reader)
+
+	if len(dom.GetElementsByTagName(doc, "title")) == 0 {
+		return ps.Parse(io.MultiReader(&scanned, input), pageURL)
+	}
+
+	// Work on the parser copy so its state isn't shared with other parses
+	ps = ps.withParseState(context.Background(), doc, pageURL)
+
+	var jsonLd map[string]string
+	if !ps.DisableJSONLD {
+		jsonLd, _ = ps.getJSONLD()
+	}
+
+	metadata := ps.getArticleMetadata(jsonLd)
+	ps.articleTitle = metadata["title"]
+
+	excerpt := metadata["excerpt"]
+	if excerpt == "" {
+		if paragraphs := dom.GetElementsByTagName(doc, "p"); len(paragraphs) > 0 {
+			excerpt = dom.TextContent(paragraphs[0])
+		}
+	}
+
+	byline := strings.ToValidUTF8(cleanByline(metadata["byline"]), "")
+
+	var replacementTitle string
+	if pageURL != nil {
+		replacementTitle = pageURL.String()
+	}
+
+	declaredReadingTime := parseReadingTime(metadata["readingTime"])
+	declaredReadingTimeSource := metadata["readingTimeSource"]
+	if declaredReadingTime == nil {
+		declaredReadingTimeSource = ""
+	}
+
+	return Article{
+		Title:                     strings.ToValidUTF8(ps.articleTitle, replacementTitle),
+		Byline:                    byline,
+		RawByline:                 strings.ToValidUTF8(metadata["byline"], ""),
+		Authors:                   ps.getAuthors(metadata, metadata["byline"]),
+		Excerpt:                   strings.ToValidUTF8(truncateText(strings.Join(strings.Fields(excerpt), " "), ps.MaxExcerptLength), ""),
+		SiteName:                  metadata["siteName"],
+		Language:                  baseLanguage(metadata["language"]),
+		LanguageTag:               metadata["language"],
+		Tags:                      splitTags(metadata["tags"]),
+		OpenGraphType:             metadata["ogType"],
+		OpenGraphURL:              metadata["ogURL"],
+		Image:                     metadata["image"],
+		Favicon:                   metadata["favicon"],
+		IsAMP:                     ps.isAMPPage(),
+		AMPURL:                    metadata["ampURL"],
+		CanonicalURL:              metadata["canonicalURL"],
+		AuthorImage:               metadata["authorImage"],
+		PublisherLogo:             metadata["publisherLogo"],
+		ThemeColor:                metadata["themeColor"],
+		ThemeColorDark:            metadata["themeColorDark"],
+		PublishedTime:             ps.getDate(metadata, "datePublished"),
+		ModifiedTime:              ps.getDate(metadata, "dateModified"),
+		DeclaredReadingTime:       declaredReadingTime,
+		DeclaredReadingTimeSource: declaredReadingTimeSource,
+	}, nil
+}
+
+// metadataTextLimit is the max number of bytes of text collected from
+// the <h1> or <p> in body, which is more than enough for the excerpt. It
+// keeps the scan short when the element is never closed.
+const metadataTextLimit = 4096
+
+// scanMetadataDocument tokenizes the input and builds a small document
+// which only contains the elements used for metadata: attributes of
+// <html>, <title>, <meta>, <link> and JSON-LD <script> in head, and the
+// first <h1> and <p> in body. The scan stops as soon as those are found.
+// Unclosed <h1> or <p> ends at the next block element, or once its text
+// reaches metadataTextLimit.
+func (ps *Parser) scanMetadataDocument(input io.Reader) *html.Node {
+	head := dom.CreateElement("head")
+	body := dom.CreateElement("body")
+	root := dom.CreateElement("html")
+	dom.AppendChild(root, head)
+	dom.AppendChild(root, body)
+
+	doc := &html.Node{Type: html.DocumentNode}
+	doc.AppendChild(root)
+
+	var current *html.Node // element whose text is being collected
+	var currentLength int
+	var h1Found, pFound bool
+	inBody := false
+
+	tokenizer := html.NewTokenizer(input)
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			// Block element can't be inside <h1> or <p>, so it means
+			// the element is implicitly closed.
+			if current != nil && current.Parent == body {
+				if _, isBlock := blockElems[token.Data]; isBlock {
+					current = nil
+				}
+			}
+
+			switch token.DataAtom {
+			case atom.Html:
+				// Keep the attributes of root, e.g. its lang.
+				for _, attr := range token.Attr {
+					dom.SetAttribute(root, attr.Key, attr.Val)
+				}
+			case atom.Body:
+				inBody = true
+			case atom.Meta, atom.Link, atom.Base:
+				dom.AppendChild(head, ps.tokenToElement(token))
+			case atom.Title:
+				if !inBody {
+					current = ps.tokenToElement(token)
+					dom.AppendChild(head, current)
+				}
+			case atom.Script:
+				if strings.Contains(strings.ToLower(tokenAttribute(token, "type")), "ld+json") {
+					current = ps.tokenToElement(token)
+					dom.AppendChild(head, current)
+				}
+			case atom.H1:
+				if !h1Found {
+					current, currentLength = ps.tokenToElement(token), 0
+					dom.AppendChild(body, current)
+					inBody, h1Found = true, true
+				}
+			case atom.P:
+				if !pFound {
+					current, currentLength = ps.tokenToElement(token), 0
+					dom.AppendChild(body, current)
+					inBody, pFound = true, true
+				}
+			}
+
+		case html.TextToken:
+			if current != nil {
+				text := token.Data
+				if current.Parent == body && currentLength+len(text) >= metadataTextLimit {
+					text = strings.ToValidUTF8(text[:metadataTextLimit-currentLength], "")
+					dom.AppendChild(current, dom.CreateTextNode(text))
+					current = nil
+					break
+				}
+
+				dom.AppendChild(current, dom.CreateTextNode(text))
+				currentLength += len(text)
+			}
+
+		case html.EndTagToken:
+			if current != nil && token.Data == current.Data {
+				current = nil
+			}
+
+			if token.DataAtom == atom.Head {
+				inBody = true
+			}
+		}
+
+		// Once we're in body, the paragraph is the last thing we need.
+		if inBody && pFound && current == nil {
+			break
+		}
+	}
+
+	return doc
+}
+
+// tokenToElement creates an element from the start tag token.
+func (ps *Parser) tokenToElement(token html.Token) *html.Node {
+	element := dom.CreateElement(token.Data)
+	for _, attr := range token.Attr {
+		dom.SetAttribute(element, attr.Key, attr.Val)
+	}
+	return element
+}
+
+// tokenAttribute returns the value of attribute in the token.
+func tokenAttribute(token html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ParseMetadataFast(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	rawHTML := `<html><head>
+		<title>Fast Title - Site</title>
+		<meta property="og:title" content="Fast Title">
+		<meta property="og:image" content="http://fakehost/cover.jpg">
+		<meta property="og:site_name" content="Fakehost">
+		<meta name="twia:reading_time" content="6">
+		<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",
+			"author":{"@type":"Person","name":"Jane Doe"},"datePublished":"2020-05-01T10:00:00Z"}</script>
+		</head><body>
+		<h1>Fast Title</h1>
+		<p>The first paragraph is used as excerpt.</p>
+		<p>` + strings.Repeat("Not needed. ", 100) + `</p>
+		</body></html>`
+
+	article, err := ParseMetadataFast(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse metadata: %v", err)
+	}
+
+	if article.Title != "Fast Title" || article.Byline != "Jane Doe" || article.SiteName != "Fakehost" ||
+		article.Image != "http://fakehost/cover.jpg" || article.Excerpt != "The first paragraph is used as excerpt." {
+		t.Errorf("\nunexpected metadata: %+v", article)
+	}
+
+	if article.PublishedTime == nil || article.PublishedTime.Year() != 2020 {
+		t.Errorf("\nwant published time in 2020, got %v", article.PublishedTime)
+	}
+
+	if article.DeclaredReadingTime == nil || *article.DeclaredReadingTime != 6*time.Minute ||
+		article.DeclaredReadingTimeSource != "twia:reading_time" {
+		t.Errorf("\nwant declared reading time 6m from twia:reading_time, got %v (%s)",
+			article.DeclaredReadingTime, article.DeclaredReadingTimeSource)
+	}
+
+	if article.Content != "" {
+		t.Errorf("\nwant no content, got %q", article.Content)
+	}
+}
+
+func Test_ParseMetadataFastFallback(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	rawHTML := articleHTML("", `<h1>Only In Body</h1>`)
+
+	article, err := ParseMetadataFast(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse metadata: %v", err)
+	}
+
+	if article.Content == "" || article.Excerpt == "" {
+		t.Errorf("\nwant full parse result, got %+v", article)
+	}
+}
+
+func Test_ParseMetadataFastUnclosedParagraph(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	excerpt := "The first paragraph is used as excerpt."
+	filler := strings.Repeat("Not <b>needed</b>. ", 10000)
+
+	scenarios := map[string]string{
+		// Unclosed paragraph ends at the next block element
+		excerpt: `<p>` + excerpt + strings.Repeat(`<div>`+filler+`</div>`, 10),
+		// or once its text is long enough.
+		excerpt + strings.Repeat(" Not needed.", 10000): `<p>` + excerpt + ` ` + filler,
+	}
+
+	for expected, bodyHTML := range scenarios {
+		input := strings.NewReader(`<html><head><title>Unclosed</title></head><body>` + bodyHTML + `</body></html>`)
+		article, err := ParseMetadataFast(input, pageURL)
+		if err != nil {
+			t.Fatalf("\nfailed to parse metadata: %v", err)
+		}
+
+		if !strings.HasPrefix(expected, article.Excerpt) || len(article.Excerpt) < len(excerpt) ||
+			len(article.Excerpt) > metadataTextLimit {
+			t.Errorf("\nunexpected excerpt with length %d: %.80q", len(article.Excerpt), article.Excerpt)
+		}
+
+		if input.Len() == 0 {
+			t.Errorf("\nwant the scan stopped early, but the whole input is read")
+		}
+	}
+}
+
+func Test_ParseMetadataFastAMP(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	rawHTML := `<html ⚡><head><title>AMP Title</title>` +
+		`<link rel="canonical" href="/article"></head>` +
+		`<body><h1>AMP Title</h1><p>The first paragraph.</p></body></html>`
+
+	article, err := ParseMetadataFast(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse metadata: %v", err)
+	}
+
+	if !article.IsAMP {
+		t.Errorf("\nwant AMP page to be detected")
+	}
+}
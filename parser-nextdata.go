@@ -0,0 +1,171 @@
+package readability
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Keys that commonly used by Next.js data blob to store the
+// article. All of them are compared in lowercase.
+var (
+	nextDataBodyKeys        = []string{"articlebody", "bodyhtml", "body", "contenthtml", "content", "html", "text"}
+	nextDataTitleKeys       = []string{"headline", "title"}
+	nextDataDateKeys        = []string{"datepublished", "publishedat", "published_at", "publishdate", "firstpublished", "date", "createdat"}
+	nextDataDescriptionKeys = []string{"description", "excerpt", "summary", "dek"}
+)
+
+// nextDataMinBodyLength is the minimum length of body in data blob
+// to be considered as article.
+const nextDataMinBodyLength = 140
+
+// extractNextData looks for the JSON data blob that used by Next.js
+// (script#__NEXT_DATA__) to hydrate the page. If the blob contains an
+// article which is longer than the text in server-rendered page, the
+// article body will be put into the document so it can be scored by
+// grabArticle. Returns metadata of the article found in the blob, if any.
+func (ps *Parser) extractNextData() map[string]string {
+	var bestArticle map[string]interface{}
+	var bestBody string
+
+	scripts := dom.GetElementsByTagName(ps.doc, "script")
+	ps.forEachNode(scripts, func(script *html.Node, _ int) {
+		if dom.ID(script) != "__NEXT_DATA__" {
+			return
+		}
+
+		content := strings.TrimSpace(dom.TextContent(script))
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			return
+		}
+
+		article, body := ps.findNextDataArticle(parsed)
+		if charCount(body) > charCount(bestBody) {
+			bestArticle, bestBody = article, body
+		}
+	})
+
+	if bestArticle == nil {
+		return nil
+	}
+
+	// Only use the article body when the server-rendered page is
+	// just an empty shell, to avoid duplicating the content.
+	bodies := dom.GetElementsByTagName(ps.doc, "body")
+	if len(bodies) > 0 {
+		// The data blob itself is located in body, so scripts must be
+		// excluded before measuring the text of the page.
+		bodyClone := dom.Clone(bodies[0], true)
+		ps.removeNodes(ps.getAllNodesWithTag(bodyClone, "script", "noscript", "style"), nil)
+
+		articleNode := ps.nextDataBodyToNode(bestBody)
+		if charCount(ps.getInnerText(bodyClone, true)) < charCount(ps.getInnerText(articleNode, true)) {
+			dom.AppendChild(bodies[0], articleNode)
+		}
+	}
+
+	metadata := make(map[string]string)
+	metadata["title"] = nextDataString(bestArticle, nextDataTitleKeys)
+	metadata["datePublished"] = nextDataString(bestArticle, nextDataDateKeys)
+	metadata["excerpt"] = nextDataString(bestArticle, nextDataDescriptionKeys)
+	return metadata
+}
+
+// findNextDataArticle walks the data blob recursively and returns the
+// object which has the longest article body. The keys of object are
+// walked in sorted order, so the first one wins when bodies are equal.
+func (ps *Parser) findNextDataArticle(data interface{}) (map[string]interface{}, string) {
+	var bestArticle map[string]interface{}
+	var bestBody string
+
+	switch val := data.(type) {
+	case map[string]interface{}:
+		if body := nextDataString(val, nextDataBodyKeys); charCount(body) >= nextDataMinBodyLength {
+			bestArticle, bestBody = val, body
+		}
+
+		for _, key := range sortedKeys(val) {
+			article, body := ps.findNextDataArticle(val[key])
+			if charCount(body) > charCount(bestBody) {
+				bestArticle, bestBody = article, body
+			}
+		}
+
+	case []interface{}:
+		for _, child := range val {
+			article, body := ps.findNextDataArticle(child)
+			if charCount(body) > charCount(bestBody) {
+				bestArticle, bestBody = article, body
+			}
+		}
+	}
+
+	return bestArticle, bestBody
+}
+
+// nextDataBodyToNode converts article body from data blob into an
+// <article> node. The body might be an HTML or just plain text, in
+// which case every blank line is treated as paragraph separator.
+func (ps *Parser) nextDataBodyToNode(body string) *html.Node {
+	article := dom.CreateElement("article")
+
+	if strings.Contains(body, "<") {
+		context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+		nodes, err := html.ParseFragment(strings.NewReader(body), context)
+		if err == nil {
+			for _, node := range nodes {
+				dom.AppendChild(article, node)
+			}
+			return article
+		}
+	}
+
+	for _, paragraph := range strings.Split(body, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		p := dom.CreateElement("p")
+		dom.AppendChild(p, dom.CreateTextNode(paragraph))
+		dom.AppendChild(article, p)
+	}
+
+	return article
+}
+
+// nextDataString returns the first non empty string value in obj
+// whose lowercased key is one of the keys. The keys are checked in
+// order, and the keys of obj which only differ in case are checked in
+// sorted order.
+func nextDataString(obj map[string]interface{}, keys []string) string {
+	objKeys := sortedKeys(obj)
+	for _, key := range keys {
+		for _, objKey := range objKeys {
+			if strings.ToLower(objKey) != key {
+				continue
+			}
+
+			if str, isString := obj[objKey].(string); isString && strings.TrimSpace(str) != "" {
+				return strings.TrimSpace(str)
+			}
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of obj in sorted order, so it can be
+// walked in the same order every time.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,58 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_extractNextData(t *testing.T) {
+	paragraph := strings.Repeat(testSentence, 12)
+	rawHTML := `<html><head><title>Shell</title></head><body><div id="__next"></div>
+		<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"article":{
+			"headline":"Hydrated headline",
+			"publishedAt":"2021-05-01T10:00:00Z",
+			"body":"<p>` + paragraph + `</p><p>` + paragraph + `</p>"}}}}</script>
+		</body></html>`
+
+	// Without the option, the shell has nothing to extract
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if strings.Contains(article.TextContent, "Lorem ipsum") {
+		t.Errorf("\nnext data used while UseNextData is disabled")
+	}
+
+	parser := NewParser()
+	parser.UseNextData = true
+	article = parseHTMLString(t, parser, rawHTML)
+
+	if !strings.Contains(article.TextContent, "Lorem ipsum") {
+		t.Errorf("\nwant content from next data, got %q", article.TextContent)
+	}
+
+	if article.Title != "Hydrated headline" {
+		t.Errorf("\n"+
+			"want : %q\n"+
+			"got  : %q", "Hydrated headline", article.Title)
+	}
+
+	if article.PublishedTime == nil || article.PublishedTime.Year() != 2021 {
+		t.Errorf("\nwant published time from next data, got %v", article.PublishedTime)
+	}
+}
+
+func Test_findNextDataArticle(t *testing.T) {
+	body := strings.Repeat(testSentence, 4)
+	data := map[string]interface{}{
+		"second": map[string]interface{}{"title": "Second", "body": body},
+		"first":  map[string]interface{}{"Title": "First (upper)", "title": "First", "body": body},
+		"third":  []interface{}{map[string]interface{}{"title": "Third", "body": body}},
+	}
+
+	// Map iteration order is random, so check it several times.
+	parser := NewParser()
+	for i := 0; i < 20; i++ {
+		article, _ := parser.findNextDataArticle(data)
+		if title := nextDataString(article, nextDataTitleKeys); title != "First (upper)" {
+			t.Fatalf("\nwant the article and title of the first keys, got %q", title)
+		}
+	}
+}
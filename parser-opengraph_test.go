@@ -0,0 +1,48 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_openGraph(t *testing.T) {
+	head := `<title>Page title | Example</title>` +
+		`<meta property="og:title" content="OG Title">` +
+		`<meta property="og:description" content="OG description of the article.">` +
+		`<meta property="og:image" content="/images/first.jpg">` +
+		`<meta property="og:image:width" content="1200">` +
+		`<meta property="og:image" content="/images/second.jpg">` +
+		`<meta property="og:site_name" content="Example News">` +
+		`<meta property="og:type" content="article">` +
+		`<meta property="og:url" content="https://example.com/news/og-title">`
+	rawHTML := articleHTML(head, `<h1>OG Title</h1>`)
+
+	parser := NewParser()
+	articles := map[string]Article{"Parse": parseHTMLString(t, parser, rawHTML)}
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	article, err := parser.ParseMetadataFast(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse metadata: %v", err)
+	}
+	articles["ParseMetadataFast"] = article
+
+	for name, article := range articles {
+		fields := []struct {
+			field, want, got string
+		}{
+			{"Title", "OG Title", article.Title},
+			{"Excerpt", "OG description of the article.", article.Excerpt},
+			{"Image", "http://fakehost/images/first.jpg", article.Image},
+			{"SiteName", "Example News", article.SiteName},
+			{"OpenGraphType", "article", article.OpenGraphType},
+			{"OpenGraphURL", "https://example.com/news/og-title", article.OpenGraphURL},
+		}
+
+		for _, field := range fields {
+			if field.got != field.want {
+				t.Errorf("\n%s: want %s %q, got %q", name, field.field, field.want, field.got)
+			}
+		}
+	}
+}
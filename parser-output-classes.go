@@ -0,0 +1,69 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Prefix of the semantic classes added by AnnotateOutput. Classes with
+// this prefix are kept when the other classes are removed.
+const outputClassPrefix = "rdbl-"
+
+// annotateOutput adds semantic classes, which listed in the doc of
+// Parser.AnnotateOutput, to the structures recognized in article content.
+// This must be run before the classes and readability attributes are
+// removed, since they are used to recognize the structures.
+func (ps *Parser) annotateOutput(articleContent *html.Node) {
+	for _, node := range ps.getAllNodesWithTag(articleContent, "figure") {
+		addClass(node, "rdbl-figure")
+	}
+
+	for _, node := range ps.getAllNodesWithTag(articleContent, "figcaption") {
+		addClass(node, "rdbl-caption")
+	}
+
+	for _, node := range ps.getAllNodesWithTag(articleContent, "blockquote", "aside") {
+		matchString := dom.ClassName(node) + " " + dom.ID(node)
+		switch {
+		case rxPullQuote.MatchString(matchString):
+			addClass(node, "rdbl-pullquote")
+		case dom.TagName(node) == "blockquote":
+			addClass(node, "rdbl-quote")
+		}
+	}
+
+	for _, node := range ps.getAllNodesWithTag(articleContent, "pre") {
+		addClass(node, "rdbl-code")
+	}
+
+	for _, node := range ps.getAllNodesWithTag(articleContent, "table") {
+		if dom.GetAttribute(node, "data-readability-table") == "true" {
+			addClass(node, "rdbl-table")
+		}
+	}
+
+	for _, node := range ps.getAllNodesWithTag(articleContent, "iframe", "video", "audio", "embed", "object") {
+		addClass(node, "rdbl-embed")
+	}
+
+	for _, node := range ps.getAllNodesWithTag(articleContent, "p") {
+		if strings.TrimSpace(dom.TextContent(node)) != "" {
+			addClass(node, "rdbl-lead")
+			break
+		}
+	}
+}
+
+// addClass appends class to the class attribute of node, if it's
+// not there yet.
+func addClass(node *html.Node, class string) {
+	classes := strings.Fields(dom.ClassName(node))
+	if indexOf(classes, class) != -1 {
+		return
+	}
+
+	classes = append(classes, class)
+	dom.SetAttribute(node, "class", strings.Join(classes, " "))
+}
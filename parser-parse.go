@@ -1,11 +1,16 @@
 package readability
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"net/http"
 	nurl "net/url"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/go-shiori/dom"
 	"golang.org/x/net/html"
@@ -13,32 +18,100 @@ import (
 
 // Parse parses a reader and find the main readable content.
 func (ps *Parser) Parse(input io.Reader, pageURL *nurl.URL) (Article, error) {
+	return ps.ParseContext(context.Background(), input, pageURL)
+}
+
+// ParseContext is like Parse, but it stops as soon as ctx is canceled or
+// its deadline is exceeded, in which case ctx.Err() is returned. It's
+// checked between the phases of parsing and inside the loops over the
+// document, so the pathological documents can be aborted.
+func (ps *Parser) ParseContext(ctx context.Context, input io.Reader, pageURL *nurl.URL) (Article, error) {
+	return ps.parse(ctx, input, pageURL, nil)
+}
+
+// parse reads input and parses it until ctx is done. If debug is not
+// nil, it's filled with the parse attempts.
+func (ps *Parser) parse(ctx context.Context, input io.Reader, pageURL *nurl.URL, debug *DebugInfo) (Article, error) {
+	// Make sure input is a HTML document
+	bufInput := bufio.NewReader(input)
+	if err := sniffContentType(bufInput); err != nil {
+		return Article{}, err
+	}
+
+	// Convert input to UTF-8
+	var reader io.Reader = bufInput
+	if ps.DetectCharset {
+		var err error
+		if reader, err = decodeCharset(bufInput); err != nil {
+			return Article{}, fmt.Errorf("failed to read input: %v", err)
+		}
+	}
+
 	// Parse input
-	doc, err := dom.Parse(input)
+	start := time.Now()
+	doc, err := dom.Parse(reader)
 	if err != nil {
 		return Article{}, fmt.Errorf("failed to parse input: %v", err)
 	}
+	parseDuration := time.Since(start)
+
+	article, err := ps.parseDocument(ctx, doc, pageURL, debug)
+	if article.Timings != nil {
+		article.Timings["parse"] = parseDuration
+	}
+
+	return article, err
+}
+
+// sniffContentType peeks the start of input to detect content that
+// obviously not a HTML document, e.g. binary files and JSON. Returns
+// ErrUnsupportedContentType in that case.
+func sniffContentType(input *bufio.Reader) error {
+	head, _ := input.Peek(512)
+	contentType := http.DetectContentType(head)
+
+	if !strings.HasPrefix(contentType, "text/") {
+		return fmt.Errorf("%w: %s", ErrUnsupportedContentType, contentType)
+	}
+
+	// JSON is sniffed as plain text, so detect it manually.
+	isJSON := false
+	trimmedHead := bytes.TrimSpace(head)
+	if bytes.HasPrefix(trimmedHead, []byte("{")) {
+		isJSON = true
+	} else if bytes.HasPrefix(trimmedHead, []byte("[")) {
+		rest := bytes.TrimSpace(trimmedHead[1:])
+		isJSON = len(rest) > 0 && bytes.IndexByte([]byte(`{["`), rest[0]) >= 0
+	}
 
-	return ps.ParseDocument(doc, pageURL)
+	if isJSON {
+		return fmt.Errorf("%w: application/json", ErrUnsupportedContentType)
+	}
+
+	return nil
 }
 
 // ParseDocument parses the specified document and find the main readable content.
 func (ps *Parser) ParseDocument(doc *html.Node, pageURL *nurl.URL) (Article, error) {
-	// Clone document to make sure the original kept untouched
-	ps.doc = dom.Clone(doc, true)
-
-	// Reset parser data
-	ps.articleTitle = ""
-	ps.articleByline = ""
-	ps.articleDir = ""
-	ps.articleSiteName = ""
-	ps.documentURI = pageURL
-	ps.attempts = []parseAttempt{}
-	ps.flags = flags{
-		stripUnlikelys:     true,
-		useWeightClasses:   true,
-		cleanConditionally: true,
+	return ps.parseDocument(context.Background(), doc, pageURL, nil)
+}
+
+// parseDocument parses the document until ctx is done. If debug is not
+// nil, it's filled with the parse attempts.
+func (ps *Parser) parseDocument(ctx context.Context, doc *html.Node, pageURL *nurl.URL, debug *DebugInfo) (Article, error) {
+	if err := ctx.Err(); err != nil {
+		return Article{}, err
+	}
+
+	var timings map[string]time.Duration
+	if ps.CollectTimings {
+		timings = make(map[string]time.Duration)
 	}
+	parseStart := time.Now()
+
+	// Clone document to make sure the original kept untouched, and
+	// work on the parser copy so its state isn't shared with other parses
+	ps = ps.withParseState(ctx, dom.Clone(doc, true), pageURL)
 
 	// Avoid parsing too large documents, as per configuration option
 	if ps.MaxElemsToParse > 0 {
@@ -48,33 +121,162 @@ func (ps *Parser) ParseDocument(doc *html.Node, pageURL *nurl.URL) (Article, err
 		}
 	}
 
+	// Check AMP components before anything is removed
+	isAMP := ps.isAMPPage()
+
 	// Unwrap image from noscript
 	ps.unwrapNoscriptImages(ps.doc)
 
 	// Extract JSON-LD metadata before removing scripts
 	var jsonLd map[string]string
+	var event *EventInfo
 	if !ps.DisableJSONLD {
+		start := time.Now()
 		jsonLd, _ = ps.getJSONLD()
+		event = ps.getEvent()
+		recordTiming(timings, "getJSONLD", start)
+	}
+
+	// Extract article from JS framework's data blob before removing
+	// scripts. JSON-LD is still preferred for the metadata.
+	if ps.UseNextData {
+		if nextData := ps.extractNextData(); nextData != nil {
+			if jsonLd == nil {
+				jsonLd = make(map[string]string)
+			}
+
+			for key, value := range nextData {
+				if jsonLd[key] == "" {
+					jsonLd[key] = value
+				}
+			}
+		}
+	}
+
+	// Move the content stashed in template into the document, so it's
+	// visible for the checks and scoring below.
+	if ps.UseTemplateContent {
+		ps.hoistTemplateContent()
+	}
+
+	// Return early for pages that obviously empty, e.g. error pages
+	// or empty shell of client-rendered site.
+	if ps.MinVisibleTextLength > 0 && !ps.hasVisibleText(ps.doc, ps.MinVisibleTextLength) {
+		return Article{}, ErrNoContent
 	}
 
 	// Remove script tags from the document.
 	ps.removeScripts(ps.doc)
 
 	// Prepares the HTML document
+	start := time.Now()
 	ps.prepDocument()
+	recordTiming(timings, "prepDocument", start)
+
+	if ps.MergeMultipleArticles {
+		ps.mergeSiblingArticles()
+	}
 
 	// Fetch metadata
 	metadata := ps.getArticleMetadata(jsonLd)
 	ps.articleTitle = metadata["title"]
+	if err := ctx.Err(); err != nil {
+		return Article{}, err
+	}
+
+	// Count comments before the comment section is removed.
+	commentCount, commentCountSource := ps.getCommentCount(jsonLd)
+
+	// Live blog entries are merged by grabArticle, so take them first.
+	var liveEntries []LiveEntry
+	if ps.DetectLiveBlog {
+		liveEntries = ps.getLiveEntries(metadata)
+	}
+
+	// Take references out before the content is scored, since it's
+	// link-dense and would be discarded by cleaning.
+	var references []Reference
+	if ps.ExtractReferences {
+		references = ps.extractReferences()
+	}
+
+	// Sponsored labels are removed along with the byline, so check it first.
+	sponsored := ps.isSponsored(jsonLd)
+
+	// Look for corrections before they're cleaned up with the content.
+	var corrections []string
+	if ps.ExtractCorrections {
+		corrections = ps.extractCorrections()
+	}
+
+	// Let the user adjust the document before it's scored.
+	if ps.PreExtractHook != nil {
+		ps.PreExtractHook(ps.doc)
+	}
 
 	// Try to grab article content
 	finalHTMLContent := ""
 	finalTextContent := ""
+	start = time.Now()
 	articleContent := ps.grabArticle()
+	recordTiming(timings, "grabArticle", start)
+	if debug != nil {
+		*debug = ps.debugInfo(articleContent)
+	}
+	if err := ctx.Err(); err != nil {
+		return Article{}, err
+	}
 	var readableNode *html.Node
+	var dateline *Dateline
+	var imageCount, videoCount int
+	var headerImage string
+	var videoPoster string
+	var quotes []QuoteInfo
+	var images []ImageInfo
+	var annotations []Annotation
+	var sections []Section
+	var summarySentences []string
+	var topics []string
+	var cutAtFold bool
+	var uncutWordCount int
 
 	if articleContent != nil {
+		start = time.Now()
+		if ps.AboveFoldOnly {
+			// The declared word count is for the whole article, so
+			// count the words before the content is cut.
+			uncutWordCount = wordCount(ps.getTextContent(articleContent))
+			cutAtFold = ps.cutAboveFold(articleContent)
+		}
+
+		// Credits are recognized by their class, so take the images
+		// before the classes are removed.
+		images = ps.getImages(articleContent, metadata)
+
 		ps.postProcessContent(articleContent)
+		recordTiming(timings, "postProcessContent", start)
+
+		// Let the user adjust the content before it's rendered.
+		if ps.PostExtractHook != nil {
+			ps.PostExtractHook(articleContent)
+		}
+
+		imageCount, videoCount = ps.countMedia(articleContent)
+		headerImage = ps.getHeaderImage(articleContent)
+
+		if isLeadVideo, poster := ps.getLeadVideoPoster(articleContent); isLeadVideo {
+			videoPoster = strOr(poster, metadata["videoThumbnail"])
+		}
+
+		if ps.NormalizeTypography {
+			ps.normalizeTypography(articleContent, ps.typographyReplacer())
+		}
+
+		quotes = ps.getQuotes(articleContent)
+
+		// Look for news dateline before the content is serialized,
+		// since it might be stripped from the content.
+		dateline = ps.extractDateline(articleContent, ps.getDate(metadata, "datePublished"))
 
 		// If we haven't found an excerpt in the article's metadata,
 		// use the article's first paragraph as the excerpt. This is used
@@ -87,20 +289,74 @@ func (ps *Parser) ParseDocument(doc *html.Node, pageURL *nurl.URL) (Article, err
 		}
 
 		readableNode = dom.FirstElementChild(articleContent)
-		finalHTMLContent = dom.InnerHTML(articleContent)
-		finalTextContent = dom.TextContent(articleContent)
+		if ps.PrettyPrintOutput {
+			finalHTMLContent = ps.prettyInnerHTML(articleContent)
+		} else {
+			finalHTMLContent = dom.InnerHTML(articleContent)
+		}
+		finalTextContent = ps.getTextContent(articleContent)
+
+		// Annotation and section offsets must follow the trimmed text content
+		annotations = ps.getAnnotations(articleContent)
+		sections = ps.getSections(articleContent)
+		leadingSpaces := len(finalTextContent) - len(strings.TrimLeftFunc(finalTextContent, unicode.IsSpace))
+		for i := range annotations {
+			annotations[i].Offset -= leadingSpaces
+		}
+
 		finalTextContent = strings.TrimSpace(finalTextContent)
+		for i := range sections {
+			sections[i].StartOffset -= leadingSpaces
+			sections[i].EndOffset -= leadingSpaces
+			if sections[i].EndOffset > len(finalTextContent) {
+				sections[i].EndOffset = len(finalTextContent)
+			}
+		}
+
+		// Repairing mojibake shortens the text, so move the annotations
+		// and sections along with it.
+		if ps.RepairMojibake {
+			if repaired := repairMojibake(finalTextContent); repaired != finalTextContent {
+				for i := range annotations {
+					start := repairedOffset(finalTextContent, annotations[i].Offset)
+					end := repairedOffset(finalTextContent, annotations[i].Offset+len(annotations[i].Text))
+					annotations[i].Offset = start
+					annotations[i].Text = repaired[start:end]
+				}
+				for i := range sections {
+					sections[i].StartOffset = repairedOffset(finalTextContent, sections[i].StartOffset)
+					sections[i].EndOffset = repairedOffset(finalTextContent, sections[i].EndOffset)
+					sections[i].Title = repairMojibake(sections[i].Title)
+				}
+				finalTextContent = repaired
+			}
+		}
+
+		if ps.ExtractSummary > 0 {
+			summarySentences = ps.getSummarySentences(articleContent, ps.ExtractSummary)
+		}
+
+		if ps.ExtractTopics {
+			topics = ps.getTopics(articleContent)
+		}
+	}
+
+	rawByline := metadata["byline"]
+	if rawByline == "" {
+		rawByline = ps.articleByline
 	}
+	finalByline := cleanByline(rawByline)
 
-	finalByline := metadata["byline"]
-	if finalByline == "" {
-		finalByline = ps.articleByline
+	finalAuthorImage := metadata["authorImage"]
+	if finalAuthorImage == "" {
+		finalAuthorImage = toAbsoluteURI(ps.articleBylineImage, pageURL)
 	}
 
 	// Excerpt is an supposed to be short and concise,
 	// so it shouldn't have any new line
 	excerpt := strings.TrimSpace(metadata["excerpt"])
 	excerpt = strings.Join(strings.Fields(excerpt), " ")
+	excerpt = truncateText(excerpt, ps.MaxExcerptLength)
 
 	// go-readability special:
 	// Internet is dangerous and weird, and sometimes we will find
@@ -114,214 +370,157 @@ func (ps *Parser) ParseDocument(doc *html.Node, pageURL *nurl.URL) (Article, err
 	validByline := strings.ToValidUTF8(finalByline, "")
 	validExcerpt := strings.ToValidUTF8(excerpt, "")
 
+	if ps.RepairMojibake {
+		validTitle = repairMojibake(validTitle)
+		validByline = repairMojibake(validByline)
+		validExcerpt = repairMojibake(validExcerpt)
+	}
+
+	declaredReadingTime := parseReadingTime(metadata["readingTime"])
+	declaredReadingTimeSource := metadata["readingTimeSource"]
+	if declaredReadingTime == nil {
+		declaredReadingTimeSource = ""
+	}
+
+	if ps.NormalizeTypography {
+		replacer := ps.typographyReplacer()
+		validTitle = replacer.Replace(validTitle)
+		validExcerpt = replacer.Replace(validExcerpt)
+	}
+
+	var warnings []string
+	extractedWordCount := wordCount(finalTextContent)
+	truncationWordCount := extractedWordCount
+	if cutAtFold {
+		truncationWordCount = uncutWordCount
+	}
+
+	declaredWordCount, truncationWarning := ps.checkTruncation(jsonLd, truncationWordCount)
+	if truncationWarning != "" {
+		warnings = append(warnings, truncationWarning)
+	}
+
 	datePublished := ps.getDate(metadata, "datePublished")
-	dateModified := ps.getDate(metadata, "dataModified")
+	dateModified := ps.getDate(metadata, "dateModified")
+	recordTiming(timings, "parseDocument", parseStart)
 
 	return Article{
-		Title:         validTitle,
-		Byline:        validByline,
-		Node:          readableNode,
-		Content:       finalHTMLContent,
-		TextContent:   finalTextContent,
-		Length:        charCount(finalTextContent),
-		Excerpt:       validExcerpt,
-		SiteName:      metadata["siteName"],
-		Image:         metadata["image"],
-		Favicon:       metadata["favicon"],
-		PublishedTime: datePublished,
-		ModifiedTime:  dateModified,
+		Title:                     validTitle,
+		Byline:                    validByline,
+		RawByline:                 strings.ToValidUTF8(rawByline, ""),
+		Authors:                   ps.getAuthors(metadata, rawByline),
+		Node:                      readableNode,
+		Content:                   finalHTMLContent,
+		TextContent:               finalTextContent,
+		Length:                    charCount(finalTextContent),
+		WordCount:                 extractedWordCount,
+		Excerpt:                   validExcerpt,
+		SiteName:                  metadata["siteName"],
+		Language:                  baseLanguage(metadata["language"]),
+		LanguageTag:               metadata["language"],
+		Tags:                      splitTags(metadata["tags"]),
+		OpenGraphType:             metadata["ogType"],
+		OpenGraphURL:              metadata["ogURL"],
+		Image:                     strOr(metadata["image"], videoPoster),
+		Favicon:                   metadata["favicon"],
+		IsAMP:                     isAMP,
+		AMPURL:                    metadata["ampURL"],
+		CanonicalURL:              metadata["canonicalURL"],
+		AuthorImage:               finalAuthorImage,
+		PublisherLogo:             metadata["publisherLogo"],
+		ThemeColor:                metadata["themeColor"],
+		ThemeColorDark:            metadata["themeColorDark"],
+		Dateline:                  dateline,
+		ImageCount:                imageCount,
+		VideoCount:                videoCount,
+		StartsWithImage:           headerImage != "",
+		HeaderImage:               headerImage,
+		VideoPoster:               videoPoster,
+		References:                references,
+		CommentCount:              commentCount,
+		CommentCountSource:        commentCountSource,
+		Quotes:                    quotes,
+		Images:                    images,
+		Event:                     event,
+		RootSelectionMethod:       ps.rootSelectionMethod,
+		Annotations:               annotations,
+		Sections:                  sections,
+		PublishedTime:             datePublished,
+		ModifiedTime:              dateModified,
+		Freshness:                 ps.getArticleFreshness(metadata, datePublished, finalTextContent),
+		DeclaredReadingTime:       declaredReadingTime,
+		DeclaredReadingTimeSource: declaredReadingTimeSource,
+		ReadingTime:               ps.estimateReadingTime(finalTextContent),
+		SummarySentences:          summarySentences,
+		Topics:                    topics,
+		Sponsored:                 sponsored,
+		Corrections:               corrections,
+		LiveEntries:               liveEntries,
+		Timings:                   timings,
+		DeclaredWordCount:         declaredWordCount,
+		Truncated:                 truncationWarning != "",
+		CutAtFold:                 cutAtFold,
+		Warnings:                  warnings,
 	}, nil
 }
 
-func (ps *Parser) getDate(metadata map[string]string, fieldName string) *time.Time {
-	dateStr, ok := metadata[fieldName]
-	if ok && len(dateStr) > 0 {
-		return getParsedDate(dateStr)
+// getAuthors returns the authors declared in JSON-LD, or the names in
+// rawByline if there are none.
+func (ps *Parser) getAuthors(metadata map[string]string, rawByline string) []string {
+	var names []string
+	if metadata["authors"] != "" {
+		names = strings.Split(metadata["authors"], "\n")
+	} else if names = bylineNames(rawByline); len(names) == 0 {
+		if byline := cleanByline(rawByline); byline != "" {
+			names = []string{byline}
+		}
 	}
-	return nil
-}
 
-func getParsedDate(dateStr string) *time.Time {
-	// Following formats have been seen in the wild.
-	formats := []string{
-		time.RFC822,  // RSS
-		time.RFC822Z, // RSS
-		time.RFC3339, // Atom
-		time.UnixDate,
-		time.RubyDate,
-		time.RFC850,
-		time.RFC1123Z,
-		time.RFC1123,
-		time.ANSIC,
-		"Mon, January 2 2006 15:04:05 -0700",
-		"Mon, January 02, 2006, 15:04:05 MST",
-		"Mon, January 02, 2006 15:04:05 MST",
-		"Mon, Jan 2, 2006 15:04 MST",
-		"Mon, Jan 2 2006 15:04 MST",
-		"Mon, Jan 2, 2006 15:04:05 MST",
-		"Mon, Jan 2 2006 15:04:05 -700",
-		"Mon, Jan 2 2006 15:04:05 -0700",
-		"Mon Jan 2 15:04 2006",
-		"Mon Jan 2 15:04:05 2006 MST",
-		"Mon Jan 02, 2006 3:04 pm",
-		"Mon, Jan 02,2006 15:04:05 MST",
-		"Mon Jan 02 2006 15:04:05 -0700",
-		"Monday, January 2, 2006 15:04:05 MST",
-		"Monday, January 2, 2006 03:04 PM",
-		"Monday, January 2, 2006",
-		"Monday, January 02, 2006",
-		"Monday, 2 January 2006 15:04:05 MST",
-		"Monday, 2 January 2006 15:04:05 -0700",
-		"Monday, 2 Jan 2006 15:04:05 MST",
-		"Monday, 2 Jan 2006 15:04:05 -0700",
-		"Monday, 02 January 2006 15:04:05 MST",
-		"Monday, 02 January 2006 15:04:05 -0700",
-		"Monday, 02 January 2006 15:04:05",
-		"Mon, 2 January 2006 15:04 MST",
-		"Mon, 2 January 2006, 15:04 -0700",
-		"Mon, 2 January 2006, 15:04:05 MST",
-		"Mon, 2 January 2006 15:04:05 MST",
-		"Mon, 2 January 2006 15:04:05 -0700",
-		"Mon, 2 January 2006",
-		"Mon, 2 Jan 2006 3:04:05 PM -0700",
-		"Mon, 2 Jan 2006 15:4:5 MST",
-		"Mon, 2 Jan 2006 15:4:5 -0700 GMT",
-		"Mon, 2, Jan 2006 15:4",
-		"Mon, 2 Jan 2006 15:04 MST",
-		"Mon, 2 Jan 2006, 15:04 -0700",
-		"Mon, 2 Jan 2006 15:04 -0700",
-		"Mon, 2 Jan 2006 15:04:05 UT",
-		"Mon, 2 Jan 2006 15:04:05MST",
-		"Mon, 2 Jan 2006 15:04:05 MST",
-		"Mon 2 Jan 2006 15:04:05 MST",
-		"mon,2 Jan 2006 15:04:05 MST",
-		"Mon, 2 Jan 2006 15:04:05 -0700 MST",
-		"Mon, 2 Jan 2006 15:04:05-0700",
-		"Mon, 2 Jan 2006 15:04:05 -0700",
-		"Mon, 2 Jan 2006 15:04:05",
-		"Mon, 2 Jan 2006 15:04",
-		"Mon,2 Jan 2006",
-		"Mon, 2 Jan 2006",
-		"Mon, 2 Jan 15:04:05 MST",
-		"Mon, 2 Jan 06 15:04:05 MST",
-		"Mon, 2 Jan 06 15:04:05 -0700",
-		"Mon, 2006-01-02 15:04",
-		"Mon,02 January 2006 14:04:05 MST",
-		"Mon, 02 January 2006",
-		"Mon, 02 Jan 2006 3:04:05 PM MST",
-		"Mon, 02 Jan 2006 15 -0700",
-		"Mon,02 Jan 2006 15:04 MST",
-		"Mon, 02 Jan 2006 15:04 MST",
-		"Mon, 02 Jan 2006 15:04 -0700",
-		"Mon, 02 Jan 2006 15:04:05 Z",
-		"Mon, 02 Jan 2006 15:04:05 UT",
-		"Mon, 02 Jan 2006 15:04:05 MST-07:00",
-		"Mon, 02 Jan 2006 15:04:05 MST -0700",
-		"Mon, 02 Jan 2006, 15:04:05 MST",
-		"Mon, 02 Jan 2006 15:04:05MST",
-		"Mon, 02 Jan 2006 15:04:05 MST",
-		"Mon , 02 Jan 2006 15:04:05 MST",
-		"Mon, 02 Jan 2006 15:04:05 GMT-0700",
-		"Mon,02 Jan 2006 15:04:05 -0700",
-		"Mon, 02 Jan 2006 15:04:05 -0700",
-		"Mon, 02 Jan 2006 15:04:05 -07:00",
-		"Mon, 02 Jan 2006 15:04:05 --0700",
-		"Mon 02 Jan 2006 15:04:05 -0700",
-		"Mon, 02 Jan 2006 15:04:05 -07",
-		"Mon, 02 Jan 2006 15:04:05 00",
-		"Mon, 02 Jan 2006 15:04:05",
-		"Mon, 02 Jan 2006",
-		"Mon, 02 Jan 06 15:04:05 MST",
-		"January 2, 2006 3:04 PM",
-		"January 2, 2006, 3:04 p.m.",
-		"January 2, 2006 15:04:05 MST",
-		"January 2, 2006 15:04:05",
-		"January 2, 2006 03:04 PM",
-		"January 2, 2006",
-		"January 02, 2006 15:04:05 MST",
-		"January 02, 2006 15:04",
-		"January 02, 2006 03:04 PM",
-		"January 02, 2006",
-		"Jan 2, 2006 3:04:05 PM MST",
-		"Jan 2, 2006 3:04:05 PM",
-		"Jan 2, 2006 15:04:05 MST",
-		"Jan 2, 2006",
-		"Jan 02 2006 03:04:05PM",
-		"Jan 02, 2006",
-		"6/1/2 15:04",
-		"6-1-2 15:04",
-		"2 January 2006 15:04:05 MST",
-		"2 January 2006 15:04:05 -0700",
-		"2 January 2006",
-		"2 Jan 2006 15:04:05 Z",
-		"2 Jan 2006 15:04:05 MST",
-		"2 Jan 2006 15:04:05 -0700",
-		"2 Jan 2006",
-		"2.1.2006 15:04:05",
-		"2/1/2006",
-		"2-1-2006",
-		"2006 January 02",
-		"2006-1-2T15:04:05Z",
-		"2006-1-2 15:04:05",
-		"2006-1-2",
-		"2006-1-02T15:04:05Z",
-		"2006-01-02T15:04Z",
-		"2006-01-02T15:04-07:00",
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05-07:00:00",
-		"2006-01-02T15:04:05:-0700",
-		"2006-01-02T15:04:05-0700",
-		"2006-01-02T15:04:05-07:00",
-		"2006-01-02T15:04:05 -0700",
-		"2006-01-02T15:04:05:00",
-		"2006-01-02T15:04:05",
-		"2006-01-02T15:04",
-		"2006-01-02 at 15:04:05",
-		"2006-01-02 15:04:05Z",
-		"2006-01-02 15:04:05 MST",
-		"2006-01-02 15:04:05-0700",
-		"2006-01-02 15:04:05-07:00",
-		"2006-01-02 15:04:05 -0700",
-		"2006-01-02 15:04",
-		"2006-01-02 00:00:00.0 15:04:05.0 -0700",
-		"2006/01/02",
-		"2006-01-02",
-		"15:04 02.01.2006 -0700",
-		"1/2/2006 3:04:05 PM MST",
-		"1/2/2006 3:04:05 PM",
-		"1/2/2006 15:04:05 MST",
-		"1/2/2006",
-		"06/1/2 15:04",
-		"06-1-2 15:04",
-		"02 Monday, Jan 2006 15:04",
-		"02 Jan 2006 15:04 MST",
-		"02 Jan 2006 15:04:05 UT",
-		"02 Jan 2006 15:04:05 MST",
-		"02 Jan 2006 15:04:05 -0700",
-		"02 Jan 2006 15:04:05",
-		"02 Jan 2006",
-		"02/01/2006 15:04 MST",
-		"02-01-2006 15:04:05 MST",
-		"02.01.2006 15:04:05",
-		"02/01/2006 15:04:05",
-		"02.01.2006 15:04",
-		"02/01/2006 - 15:04",
-		"02.01.2006 -0700",
-		"02/01/2006",
-		"02-01-2006",
-		"01/02/2006 3:04 PM",
-		"01/02/2006 15:04:05 MST",
-		"01/02/2006 - 15:04",
-		"01/02/2006",
-		"01-02-2006",
-	}
-	for i, format := range formats {
-		parsedDate, err := time.Parse(format, dateStr)
-		if err == nil {
-			return &parsedDate
-		} else if i == len(formats)-1 {
-			fmt.Printf("Failed to parse date \"%s\"\n", dateStr)
+	var authors []string
+	for _, author := range names {
+		author = strings.ToValidUTF8(author, "")
+		if ps.RepairMojibake {
+			author = repairMojibake(author)
 		}
+		authors = append(authors, author)
 	}
-	return nil
+	return authors
+}
+
+// recordTiming adds the time elapsed since start to the duration of
+// phase in timings. Does nothing if timings is nil, i.e. the timings
+// are not collected.
+func recordTiming(timings map[string]time.Duration, phase string, start time.Time) {
+	if timings != nil {
+		timings[phase] += time.Since(start)
+	}
+}
+
+// CleanContent runs the cleaning passes of readability (e.g. removing
+// junk elements, fixing lazy images and resolving relative URLs) on HTML
+// which already known to be the article content, without trying to find
+// the readable content first. Returns the cleaned HTML.
+func (ps *Parser) CleanContent(contentHTML string, pageURL *nurl.URL) (string, error) {
+	doc, err := dom.Parse(strings.NewReader("<html><body>" + contentHTML + "</body></html>"))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse content: %v", err)
+	}
+
+	// Work on the parser copy so its state isn't shared with other parses
+	ps = ps.withParseState(context.Background(), doc, pageURL)
+
+	ps.unwrapNoscriptImages(ps.doc)
+	ps.removeScripts(ps.doc)
+	ps.prepDocument()
+
+	body := dom.GetElementsByTagName(ps.doc, "body")[0]
+	articleContent := dom.CreateElement("div")
+	for _, child := range dom.ChildNodes(body) {
+		dom.AppendChild(articleContent, child)
+	}
+
+	ps.prepArticle(articleContent)
+	ps.postProcessContent(articleContent)
+
+	return dom.InnerHTML(articleContent), nil
 }
@@ -0,0 +1,131 @@
+package readability
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// plainTextWriter writes the text of plain text renderer. The line
+// breaks between blocks are deferred until the next text is written, so
+// there's no dangling break at the start and the end of output.
+type plainTextWriter struct {
+	buf strings.Builder
+	// pendingBreaks is the number of line breaks to write before the
+	// next text.
+	pendingBreaks int
+	// pendingSpace is true if a space should be written before the next
+	// text in the same line.
+	pendingSpace bool
+}
+
+// writeText writes text whose whitespaces are collapsed.
+func (w *plainTextWriter) writeText(text string) {
+	if text == "" {
+		return
+	}
+
+	if firstRune, _ := utf8.DecodeRuneInString(text); unicode.IsSpace(firstRune) {
+		w.pendingSpace = true
+	}
+
+	words := strings.Fields(text)
+	for i, word := range words {
+		w.writeRaw(word)
+		if i < len(words)-1 {
+			w.pendingSpace = true
+		}
+	}
+
+	if lastRune, _ := utf8.DecodeLastRuneInString(text); unicode.IsSpace(lastRune) {
+		w.pendingSpace = true
+	}
+}
+
+// writeRaw writes text as it is, after the pending line breaks or space.
+func (w *plainTextWriter) writeRaw(text string) {
+	if text == "" {
+		return
+	}
+
+	if w.buf.Len() > 0 {
+		switch {
+		case w.pendingBreaks > 0:
+			w.buf.WriteString(strings.Repeat("\n", w.pendingBreaks))
+		case w.pendingSpace:
+			w.buf.WriteByte(' ')
+		}
+	}
+
+	w.buf.WriteString(text)
+	w.pendingBreaks = 0
+	w.pendingSpace = false
+}
+
+// lineBreak requests n line breaks before the next text. Consecutive
+// requests are merged into the largest one.
+func (w *plainTextWriter) lineBreak(n int) {
+	if n > w.pendingBreaks {
+		w.pendingBreaks = n
+	}
+}
+
+// PlainText renders the article content as plain text which is readable
+// in terminal and email. Unlike TextContent, the blocks are separated by
+// blank line, the list items and <br> are put in separate lines, and the
+// text of <pre> keeps its line breaks. Returns empty string if the
+// article has no content.
+func (article Article) PlainText() string {
+	if article.Node == nil {
+		return ""
+	}
+
+	var w plainTextWriter
+	var walker func(*html.Node)
+	walker = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			w.writeText(node.Data)
+			return
+		case html.ElementNode, html.DocumentNode:
+		default:
+			return
+		}
+
+		tagName := dom.TagName(node)
+		switch tagName {
+		case "br":
+			w.lineBreak(1)
+			return
+		case "pre":
+			w.lineBreak(2)
+			w.writeRaw(strings.Trim(dom.TextContent(node), "\n"))
+			w.lineBreak(2)
+			return
+		}
+
+		breaks := 0
+		switch tagName {
+		case "li", "dt", "dd", "tr", "figcaption":
+			breaks = 1
+		case "td", "th":
+			w.pendingSpace = true
+		default:
+			if _, isBlock := blockElems[tagName]; isBlock {
+				breaks = 2
+			}
+		}
+
+		w.lineBreak(breaks)
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walker(child)
+		}
+		w.lineBreak(breaks)
+	}
+
+	walker(article.Node)
+	return w.buf.String()
+}
@@ -0,0 +1,36 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_PlainText(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<h2>Second   section</h2>` +
+		`<p>First paragraph.</p>` +
+		"<p>Second\n   paragraph with <em>inline</em> text<br>and a line break.</p>" +
+		`<ul><li>One</li><li>Two</li></ul>` +
+		`<blockquote><p>Quoted text.</p></blockquote>` +
+		"<pre>if ok {\n    return\n}</pre>" +
+		testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	lorem := strings.TrimSpace(strings.Repeat(testSentence, 12))
+	expected := lorem + "\n\n" +
+		"Second section\n\n" +
+		"First paragraph.\n\n" +
+		"Second paragraph with inline text\nand a line break.\n\n" +
+		"One\nTwo\n\n" +
+		"Quoted text.\n\n" +
+		"if ok {\n    return\n}\n\n" +
+		lorem
+
+	if got := article.PlainText(); got != expected {
+		t.Errorf("\nwant:\n%s\n\ngot:\n%s", expected, got)
+	}
+
+	if got := (Article{}).PlainText(); got != "" {
+		t.Errorf("\nwant empty text for empty article, got %q", got)
+	}
+}
@@ -0,0 +1,82 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// prettyIndent is the indentation of each level in pretty printed HTML.
+const prettyIndent = "  "
+
+// prettyInnerHTML serializes the children of node with one block element
+// per line, indented by its depth. Text and inline elements are kept on
+// the line of their block, and the content of <pre> and <textarea> is
+// written verbatim since their whitespace is significant.
+func (ps *Parser) prettyInnerHTML(node *html.Node) string {
+	var sb strings.Builder
+	ps.writePrettyChildren(&sb, node, 0)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// writePrettyChildren writes the children of node at the given depth.
+// The consecutive inline children are written together as a single line.
+func (ps *Parser) writePrettyChildren(sb *strings.Builder, node *html.Node, depth int) {
+	var inline strings.Builder
+	flushInline := func() {
+		if line := strings.TrimSpace(inline.String()); line != "" {
+			sb.WriteString(strings.Repeat(prettyIndent, depth))
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+		inline.Reset()
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if _, isBlock := blockElems[dom.TagName(child)]; !isBlock || child.Type != html.ElementNode {
+			html.Render(&inline, child)
+			continue
+		}
+
+		flushInline()
+		ps.writePrettyElement(sb, child, depth)
+	}
+
+	flushInline()
+}
+
+// writePrettyElement writes block element at the given depth. Element
+// without any block children is written as a single line.
+func (ps *Parser) writePrettyElement(sb *strings.Builder, element *html.Node, depth int) {
+	indent := strings.Repeat(prettyIndent, depth)
+	tagName := dom.TagName(element)
+
+	hasBlockChild := ps.someNode(dom.Children(element), func(child *html.Node) bool {
+		_, isBlock := blockElems[dom.TagName(child)]
+		return isBlock
+	})
+
+	if tagName == "pre" || !hasBlockChild {
+		sb.WriteString(indent)
+		sb.WriteString(dom.OuterHTML(element))
+		sb.WriteString("\n")
+		return
+	}
+
+	sb.WriteString(indent)
+	sb.WriteString("<" + tagName)
+	for _, attr := range element.Attr {
+		key := attr.Key
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+		sb.WriteString(" " + key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	sb.WriteString(">\n")
+
+	ps.writePrettyChildren(sb, element, depth+1)
+
+	sb.WriteString(indent)
+	sb.WriteString("</" + tagName + ">\n")
+}
@@ -0,0 +1,38 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_prettyPrintOutput(t *testing.T) {
+	code := "<pre>func main() {\n    fmt.Println(x)\n}</pre>"
+	rawHTML := `<html><body><article>` + testParagraph + code +
+		`<ul><li>One <em>item</em></li><li>Two</li></ul>` + testParagraph + `</article></body></html>`
+
+	compact := parseHTMLString(t, NewParser(), rawHTML)
+	if strings.Contains(compact.Content, "\n  <") {
+		t.Errorf("\nwant compact content by default, got %s", compact.Content)
+	}
+
+	parser := NewParser()
+	parser.PrettyPrintOutput = true
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if !strings.Contains(article.Content, "\n    <ul>\n      <li>One <em>item</em></li>\n      <li>Two</li>\n    </ul>\n") {
+		t.Errorf("\nwant indented list, got %s", article.Content)
+	}
+
+	if !strings.Contains(article.Content, code) {
+		t.Errorf("\nwant pre to be kept verbatim, got %s", article.Content)
+	}
+
+	if !strings.HasPrefix(article.Content, `<div id="readability-page-1" class="page">`+"\n") ||
+		!strings.HasSuffix(article.Content, "\n</div>") {
+		t.Errorf("\nwant page container on its own lines, got %s", article.Content)
+	}
+
+	if article.TextContent != compact.TextContent {
+		t.Errorf("\nwant text content to be unchanged, got %q", article.TextContent)
+	}
+}
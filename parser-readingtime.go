@@ -0,0 +1,163 @@
+package readability
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Settings for estimating the reading time.
+const (
+	// defaultWordsPerMinute is the reading speed used when
+	// Parser.WordsPerMinute is not set.
+	defaultWordsPerMinute = 200
+	// cjkCharsPerWord is the number of CJK chars which read in the same
+	// time as a word, so 200 words per minute is 500 chars per minute.
+	cjkCharsPerWord = 2.5
+)
+
+// getDeclaredReadingTime looks for the reading time which declared by
+// the publisher. The conventions that checked, in order, are:
+//
+// "json-ld": timeRequired property of the article in JSON-LD.
+//
+// "twia:reading_time": custom meta whose content is the number of
+// minutes.
+//
+// "twitter:data": twitter:dataN meta whose twitter:labelN meta says
+// it's reading time (e.g. "Est. reading time"), emitted by WordPress.
+//
+// Returns the raw value and the convention it's taken from.
+func (ps *Parser) getDeclaredReadingTime(jsonLd map[string]string) (string, string) {
+	if jsonLd["timeRequired"] != "" {
+		return jsonLd["timeRequired"], "json-ld"
+	}
+
+	labels := make(map[string]string)
+	data := make(map[string]string)
+	var twiaReadingTime string
+
+	metaElements := dom.GetElementsByTagName(ps.doc, "meta")
+	ps.forEachNode(metaElements, func(element *html.Node, _ int) {
+		name := strOr(dom.GetAttribute(element, "name"), dom.GetAttribute(element, "property"))
+		name = strings.ToLower(strings.TrimSpace(name))
+		content := strings.TrimSpace(dom.GetAttribute(element, "content"))
+		if content == "" {
+			return
+		}
+
+		switch {
+		case name == "twia:reading_time":
+			twiaReadingTime = content
+		case strings.HasPrefix(name, "twitter:label"):
+			labels[strings.TrimPrefix(name, "twitter:label")] = content
+		case strings.HasPrefix(name, "twitter:data"):
+			data[strings.TrimPrefix(name, "twitter:data")] = content
+		}
+	})
+
+	if twiaReadingTime != "" {
+		return twiaReadingTime, "twia:reading_time"
+	}
+
+	// Check the labels in numeric order of their index, so the result is
+	// stable and "twitter:label2" comes before "twitter:label10".
+	indexes := make([]string, 0, len(labels))
+	for idx := range labels {
+		indexes = append(indexes, idx)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, errA := strconv.Atoi(indexes[i])
+		b, errB := strconv.Atoi(indexes[j])
+		if errA != nil || errB != nil {
+			return errA == nil || (errB != nil && indexes[i] < indexes[j])
+		}
+		return a < b
+	})
+
+	for _, idx := range indexes {
+		if rxReadingTimeLabel.MatchString(labels[idx]) && data[idx] != "" {
+			return data[idx], "twitter:data"
+		}
+	}
+
+	return "", ""
+}
+
+// parseReadingTime converts the declared reading time into duration.
+// It accepts ISO 8601 duration (e.g. "PT5M"), text like "5 minutes",
+// "1 hour 10 min" or "5 min read", and a bare number of minutes.
+func parseReadingTime(str string) *time.Duration {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return nil
+	}
+
+	if parts := rxISO8601Duration.FindStringSubmatch(str); parts != nil {
+		var duration time.Duration
+		units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+		for i, unit := range units {
+			if value, err := strconv.Atoi(parts[i+1]); err == nil {
+				duration += time.Duration(value) * unit
+			}
+		}
+
+		if duration <= 0 {
+			return nil
+		}
+		return &duration
+	}
+
+	var duration time.Duration
+	if parts := rxReadingTimeHours.FindStringSubmatch(str); parts != nil {
+		hours, _ := strconv.ParseFloat(parts[1], 64)
+		duration += time.Duration(hours * float64(time.Hour))
+		str = strings.Replace(str, parts[0], "", 1)
+	}
+
+	if parts := rxReadingTimeMinutes.FindStringSubmatch(str); parts != nil {
+		minutes, _ := strconv.ParseFloat(parts[1], 64)
+		duration += time.Duration(minutes * float64(time.Minute))
+	}
+
+	if duration <= 0 {
+		return nil
+	}
+	return &duration
+}
+
+// estimateReadingTime estimates the time to read text using the words
+// per minute of parser. Han, Hiragana and Katakana chars are not
+// separated by space, so they are counted by chars instead of words.
+func (ps *Parser) estimateReadingTime(text string) time.Duration {
+	wordsPerMinute := ps.WordsPerMinute
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = defaultWordsPerMinute
+	}
+
+	var nWords, nCJKChars int
+	for _, field := range strings.Fields(text) {
+		hasWord := false
+		for _, r := range field {
+			switch {
+			case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+				nCJKChars++
+			case unicode.IsLetter(r) || unicode.IsDigit(r):
+				hasWord = true
+			}
+		}
+
+		if hasWord {
+			nWords++
+		}
+	}
+
+	words := float64(nWords) + float64(nCJKChars)/cjkCharsPerWord
+	minutes := words / float64(wordsPerMinute)
+	return (time.Duration(minutes * float64(time.Minute))).Round(time.Second)
+}
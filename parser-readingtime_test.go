@@ -0,0 +1,94 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_parseReadingTime(t *testing.T) {
+	scenarios := map[string]time.Duration{
+		"PT5M":          5 * time.Minute,
+		"PT1H30M":       90 * time.Minute,
+		"5 minutes":     5 * time.Minute,
+		"7 min read":    7 * time.Minute,
+		"1 hour 10 min": 70 * time.Minute,
+		"12":            12 * time.Minute,
+		"":              0,
+		"soon":          0,
+	}
+
+	for str, expected := range scenarios {
+		result := parseReadingTime(str)
+		if expected == 0 {
+			if result != nil {
+				t.Errorf("\n%q: want nil, got %v", str, *result)
+			}
+			continue
+		}
+
+		if result == nil || *result != expected {
+			t.Errorf("\n%q: want %v, got %v", str, expected, result)
+		}
+	}
+}
+
+func Test_declaredReadingTime(t *testing.T) {
+	type result struct {
+		duration time.Duration
+		source   string
+	}
+
+	scenarios := map[string]result{
+		`<meta name="twitter:label1" content="Written by"><meta name="twitter:data1" content="Jane Doe">` +
+			`<meta name="twitter:label2" content="Est. reading time"><meta name="twitter:data2" content="4 minutes">`: {4 * time.Minute, "twitter:data"},
+		`<meta name="twitter:label10" content="Reading time"><meta name="twitter:data10" content="9 minutes">` +
+			`<meta name="twitter:label2" content="Est. reading time"><meta name="twitter:data2" content="3 minutes">`: {3 * time.Minute, "twitter:data"},
+		`<meta name="twia:reading_time" content="6">`: {6 * time.Minute, "twia:reading_time"},
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","timeRequired":"PT8M"}</script>` +
+			`<meta name="twia:reading_time" content="6">`: {8 * time.Minute, "json-ld"},
+		`<meta name="twitter:label1" content="Written by"><meta name="twitter:data1" content="Jane Doe">`: {},
+	}
+
+	for head, expected := range scenarios {
+		rawHTML := articleHTML(head, "")
+		article := parseHTMLString(t, NewParser(), rawHTML)
+
+		var duration time.Duration
+		if article.DeclaredReadingTime != nil {
+			duration = *article.DeclaredReadingTime
+		}
+
+		if duration != expected.duration || article.DeclaredReadingTimeSource != expected.source {
+			t.Errorf("\n"+
+				"head : %s\n"+
+				"want : %v (%s)\n"+
+				"got  : %v (%s)", head,
+				expected.duration, expected.source,
+				duration, article.DeclaredReadingTimeSource)
+		}
+	}
+}
+
+func Test_estimateReadingTime(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		text           string
+		wordsPerMinute int
+		want           time.Duration
+	}{
+		{"empty", "", 0, 0},
+		{"default speed", strings.Repeat("word ", 400), 0, 2 * time.Minute},
+		{"custom speed", strings.Repeat("word ", 400), 100, 4 * time.Minute},
+		{"chinese", strings.Repeat("中文字", 250), 0, 90 * time.Second},
+		{"japanese and latin", strings.Repeat("東京 Tokyo ", 100), 0, 54 * time.Second},
+	}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.WordsPerMinute = scenario.wordsPerMinute
+		if got := parser.estimateReadingTime(scenario.text); got != scenario.want {
+			t.Errorf("\n%s: want %v, got %v", scenario.name, scenario.want, got)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Reference is an item in the references or sources list of the
+// article, e.g. the cited paper or the source news.
+type Reference struct {
+	Text string
+	URL  string
+}
+
+// extractReferences looks for the references section in the document,
+// i.e. element whose class or id says it's a list of references (e.g.
+// "references" or "reflist"), or list which placed right after heading
+// like "Sources" or "Bibliography". Since those are usually link-dense,
+// the section will be removed from the document so it doesn't get mixed
+// into (or discarded from) the main content. Returns the list items.
+func (ps *Parser) extractReferences() []Reference {
+	// Heading of each section, if the section is found by its heading.
+	var sections []*html.Node
+	headings := make(map[*html.Node]*html.Node)
+	docTextLength := charCount(ps.getInnerText(ps.doc, true))
+
+	ps.forEachNode(dom.GetElementsByTagName(ps.doc, "*"), func(node *html.Node, _ int) {
+		switch dom.TagName(node) {
+		case "ol", "ul", "div", "section", "aside":
+			if rxReferences.MatchString(dom.ClassName(node)+" "+dom.ID(node)) &&
+				ps.isReferencesSection(node, docTextLength) {
+				sections = append(sections, node)
+			}
+
+		case "h2", "h3", "h4":
+			if !rxReferencesHeading.MatchString(ps.getInnerText(node, true)) {
+				return
+			}
+
+			list := dom.NextElementSibling(node)
+			if list != nil && (dom.TagName(list) == "ol" || dom.TagName(list) == "ul") {
+				sections = append(sections, list)
+				headings[list] = node
+			}
+		}
+	})
+
+	var references []Reference
+	var extracted []*html.Node
+	for _, section := range sections {
+		// Skip section that nested inside the extracted one.
+		if ps.someNode(extracted, func(node *html.Node) bool { return isAncestor(node, section) }) {
+			continue
+		}
+
+		items := dom.GetElementsByTagName(section, "li")
+		for _, item := range items {
+			reference := Reference{Text: ps.getInnerText(item, true)}
+			for _, link := range dom.GetElementsByTagName(item, "a") {
+				href := strings.TrimSpace(dom.GetAttribute(link, "href"))
+				if href != "" && !strings.HasPrefix(href, "#") && !isUnsafeURL(href) {
+					reference.URL = toAbsoluteURI(href, ps.documentURI)
+					break
+				}
+			}
+
+			if reference.Text != "" {
+				references = append(references, reference)
+			}
+		}
+
+		if len(items) > 0 {
+			extracted = append(extracted, section)
+			if heading, hasHeading := headings[section]; hasHeading {
+				extracted = append(extracted, heading)
+			}
+		}
+	}
+
+	ps.removeNodes(extracted, nil)
+	return references
+}
+
+// isReferencesSection checks if node whose class or id looks like
+// references is really the references section, and not a wrapper whose
+// class merely contains the word (e.g. "article sources-inline"). Node
+// which contains most of the text in document or the semantic container
+// of article is never accepted, since it's where the content is. Else it
+// must start with references heading, or placed after most of the text.
+func (ps *Parser) isReferencesSection(node *html.Node, docTextLength int) bool {
+	textLength := charCount(ps.getInnerText(node, true))
+	if textLength*2 > docTextLength || len(ps.getAllNodesWithTag(node, "article", "main")) > 0 {
+		return false
+	}
+
+	if first := dom.FirstElementChild(node); first != nil {
+		switch dom.TagName(first) {
+		case "h2", "h3", "h4":
+			if rxReferencesHeading.MatchString(ps.getInnerText(first, true)) {
+				return true
+			}
+		}
+	}
+
+	return ps.textLengthBefore(node)*2 >= docTextLength-textLength
+}
+
+// textLengthBefore returns the number of chars in the text which placed
+// before node in the document.
+func (ps *Parser) textLengthBefore(node *html.Node) int {
+	var text strings.Builder
+	var walk func(*html.Node) bool
+	walk = func(current *html.Node) bool {
+		if current == node {
+			return true
+		}
+
+		if current.Type == html.TextNode {
+			text.WriteString(current.Data)
+		}
+
+		for child := current.FirstChild; child != nil; child = child.NextSibling {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+
+	walk(ps.doc)
+	return charCount(strings.Join(strings.Fields(text.String()), " "))
+}
+
+// isAncestor checks if ancestor is one of the ancestors of node.
+func isAncestor(ancestor, node *html.Node) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if parent == ancestor {
+			return true
+		}
+	}
+	return false
+}
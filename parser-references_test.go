@@ -0,0 +1,96 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_extractReferences(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph + testParagraph +
+		`<h2>Sources</h2><ol>` +
+		`<li><a href="#cite-1">^</a> Doe, J. (2020). <a href="/papers/doe-2020.pdf">A study</a>.</li>` +
+		`<li>Smith, A. <a href="https://example.com/smith">Another study</a>.</li>` +
+		`<li>An offline book.</li>` +
+		`</ol></article></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	if len(article.References) != 0 {
+		t.Errorf("\nwant no references by default, got %+v", article.References)
+	}
+
+	parser.ExtractReferences = true
+	article = parseHTMLString(t, parser, rawHTML)
+
+	expected := []Reference{
+		{Text: "^ Doe, J. (2020). A study.", URL: "http://fakehost/papers/doe-2020.pdf"},
+		{Text: "Smith, A. Another study.", URL: "https://example.com/smith"},
+		{Text: "An offline book."},
+	}
+
+	if len(article.References) != len(expected) {
+		t.Fatalf("\nwant %d references, got %+v", len(expected), article.References)
+	}
+
+	for i, reference := range article.References {
+		if reference != expected[i] {
+			t.Errorf("\n"+
+				"want : %+v\n"+
+				"got  : %+v", expected[i], reference)
+		}
+	}
+
+	if strings.Contains(article.Content, "Sources") || strings.Contains(article.Content, "offline book") {
+		t.Errorf("\nwant references kept out of content, got %s", article.Content)
+	}
+}
+
+func Test_extractReferencesByClass(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph + testParagraph +
+		`<div class="reflist"><ol class="references">` +
+		`<li><a href="https://example.com/a">First</a></li>` +
+		`<li><a href="https://example.com/b">Second</a></li>` +
+		`</ol></div></article></body></html>`
+
+	parser := NewParser()
+	parser.ExtractReferences = true
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if len(article.References) != 2 || article.References[1].URL != "https://example.com/b" {
+		t.Errorf("\nwant 2 references, got %+v", article.References)
+	}
+}
+
+func Test_extractReferencesWrapper(t *testing.T) {
+	scenarios := map[string]string{
+		"wrapper of content": `<div class="article sources-inline">` + testParagraph + testParagraph +
+			`<ul><li>Related</li></ul></div>`,
+		"list before content": `<article><ul class="sources"><li>Reuters</li><li>AP</li></ul>` +
+			testParagraph + testParagraph + `</article>`,
+	}
+
+	for name, bodyHTML := range scenarios {
+		parser := NewParser()
+		parser.ExtractReferences = true
+		article := parseHTMLString(t, parser, `<html><body>`+bodyHTML+`</body></html>`)
+
+		if len(article.References) != 0 {
+			t.Errorf("\n%s: want no references, got %+v", name, article.References)
+		}
+
+		if !strings.Contains(article.TextContent, "Lorem ipsum") {
+			t.Errorf("\n%s: want content kept, got %q", name, article.TextContent)
+		}
+	}
+
+	// Section which starts with references heading is accepted anywhere.
+	rawHTML := `<html><body><article><section id="sources"><h2>Sources</h2><ul><li>Reuters</li></ul></section>` +
+		testParagraph + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.ExtractReferences = true
+	article := parseHTMLString(t, parser, rawHTML)
+	if len(article.References) != 1 || article.References[0].Text != "Reuters" {
+		t.Errorf("\nwant 1 reference, got %+v", article.References)
+	}
+}
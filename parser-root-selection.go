@@ -0,0 +1,44 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// The ways the root of article content might be chosen, reported in
+// Article.RootSelectionMethod.
+const (
+	// RootSelectionSemantic means the root chosen by scoring is the
+	// semantic container of article, i.e. <article>, <main> or element
+	// with role "main" or itemprop "articleBody".
+	RootSelectionSemantic = "semantic"
+	// RootSelectionHeuristic means the root is chosen by scoring and
+	// it's not a semantic container.
+	RootSelectionHeuristic = "heuristic"
+	// RootSelectionBody means no candidate is found, so the whole body
+	// is used as the root.
+	RootSelectionBody = "body"
+	// RootSelectionLongestAttempt means none of the attempts passed
+	// the char threshold, so the attempt with the longest text is used.
+	RootSelectionLongestAttempt = "longest-attempt"
+)
+
+// getRootSelectionMethod returns how the top candidate is chosen. See
+// the RootSelection constants for the possible values.
+func (ps *Parser) getRootSelectionMethod(topCandidate *html.Node, neededToCreateTopCandidate bool) string {
+	if neededToCreateTopCandidate {
+		return RootSelectionBody
+	}
+
+	switch {
+	case dom.TagName(topCandidate) == "article",
+		dom.TagName(topCandidate) == "main",
+		strings.EqualFold(dom.GetAttribute(topCandidate, "role"), "main"),
+		strings.EqualFold(dom.GetAttribute(topCandidate, "itemprop"), "articleBody"):
+		return RootSelectionSemantic
+	}
+
+	return RootSelectionHeuristic
+}
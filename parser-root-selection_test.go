@@ -0,0 +1,26 @@
+package readability
+
+import (
+	"testing"
+)
+
+func Test_getRootSelectionMethod(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		body   string
+		method string
+	}{
+		{"article", `<article>` + testParagraph + testParagraph + `</article>`, RootSelectionSemantic},
+		{"role main", `<div role="main">` + testParagraph + testParagraph + `</div>`, RootSelectionSemantic},
+		{"div", `<div class="content">` + testParagraph + testParagraph + `</div>`, RootSelectionHeuristic},
+		{"short page", `<p>Hello world.</p>`, RootSelectionLongestAttempt},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><body>` + scenario.body + `</body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.RootSelectionMethod != scenario.method {
+			t.Errorf("\n%s: want method %q, got %q", scenario.name, scenario.method, article.RootSelectionMethod)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Section is a top-level section of the article, which starts at <h2>
+// and ends right before the next <h2> or at the end of the content.
+type Section struct {
+	// Title is the text of the section's heading.
+	Title string
+	// Anchor is the id of the heading (or of an anchor inside it), which
+	// can be used to link to the section. Empty if it doesn't have any.
+	Anchor string
+	// StartOffset is the byte offset in the article's TextContent where
+	// the section starts, i.e. the start of its heading.
+	StartOffset int
+	// EndOffset is the byte offset in the article's TextContent where
+	// the section ends, exclusive.
+	EndOffset int
+}
+
+// getSections splits the article content into sections at <h2>. The
+// text before the first <h2> doesn't belong to any section. The offsets
+// are counted from the start of article content's text, the same way
+// as annotations.
+func (ps *Parser) getSections(articleContent *html.Node) []Section {
+	var sections []Section
+	text := ps.buildTextContent(articleContent, func(node *html.Node, tb *textBuilder, entering bool) {
+		if !entering || dom.TagName(node) != "h2" {
+			return
+		}
+
+		if len(sections) > 0 {
+			sections[len(sections)-1].EndOffset = tb.Len()
+		}
+
+		sections = append(sections, Section{
+			Title:       strings.Join(strings.Fields(dom.TextContent(node)), " "),
+			Anchor:      ps.getHeadingAnchor(node),
+			StartOffset: tb.Len(),
+		})
+	})
+
+	if len(sections) > 0 {
+		sections[len(sections)-1].EndOffset = len(text)
+	}
+
+	return sections
+}
+
+// getHeadingAnchor returns the id of heading, or the id or name of the
+// first anchor inside it.
+func (ps *Parser) getHeadingAnchor(heading *html.Node) string {
+	if id := strings.TrimSpace(dom.ID(heading)); id != "" {
+		return id
+	}
+
+	for _, anchor := range dom.GetElementsByTagName(heading, "a") {
+		if id := strings.TrimSpace(strOr(dom.ID(anchor), dom.GetAttribute(anchor, "name"))); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
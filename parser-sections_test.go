@@ -0,0 +1,77 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_getSections(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat(testSentence, 6) + "</p>"
+	rawHTML := `<html><body><article>` + paragraph +
+		`<h2 id="setup">Setup</h2>` + paragraph + paragraph +
+		`<h2><a name="usage"></a>Usage</h2>` + paragraph +
+		`</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if len(article.Sections) != 2 {
+		t.Fatalf("\nwant 2 sections, got %+v", article.Sections)
+	}
+
+	expected := []struct {
+		title  string
+		anchor string
+	}{
+		{"Setup", "setup"},
+		{"Usage", "usage"},
+	}
+
+	for i, section := range article.Sections {
+		if section.Title != expected[i].title || section.Anchor != expected[i].anchor {
+			t.Errorf("\nwant section %q #%s, got %+v", expected[i].title, expected[i].anchor, section)
+		}
+
+		text := article.TextContent[section.StartOffset:section.EndOffset]
+		if !strings.HasPrefix(text, expected[i].title) {
+			t.Errorf("\nwant section text starts with %q, got %.40q", expected[i].title, text)
+		}
+	}
+
+	if article.Sections[0].EndOffset != article.Sections[1].StartOffset {
+		t.Errorf("\nwant first section to end at the next heading, got %+v", article.Sections)
+	}
+
+	if article.Sections[1].EndOffset != len(article.TextContent) {
+		t.Errorf("\nwant last section to end at the end of text, got %+v", article.Sections[1])
+	}
+}
+
+func Test_getSectionsMojibake(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("Le cafÃ© est trÃ¨s bon. ", 12) + "</p>"
+	rawHTML := `<html><body><article>` + paragraph +
+		`<h2>PrÃ©paration</h2>` + paragraph + paragraph +
+		`<h2>DÃ©gustation</h2>` + paragraph +
+		`</article></body></html>`
+
+	parser := NewParser()
+	parser.RepairMojibake = true
+	article := parseHTMLString(t, parser, rawHTML)
+	if len(article.Sections) != 2 {
+		t.Fatalf("\nwant 2 sections, got %+v", article.Sections)
+	}
+
+	for i, title := range []string{"Préparation", "Dégustation"} {
+		section := article.Sections[i]
+		if section.Title != title {
+			t.Errorf("\nwant repaired section title %q, got %q", title, section.Title)
+		}
+
+		text := article.TextContent[section.StartOffset:section.EndOffset]
+		if !strings.HasPrefix(text, title) {
+			t.Errorf("\nwant section text starts with %q, got %.40q", title, text)
+		}
+	}
+
+	if article.Sections[1].EndOffset != len(article.TextContent) {
+		t.Errorf("\nwant last section to end at the end of text, got %+v", article.Sections[1])
+	}
+}
@@ -0,0 +1,78 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// removeShareButtons removes clusters of social share buttons (e.g.
+// "Share on Twitter" and "Share on Facebook" links) that survived in
+// article content. A cluster is an element which only contains share
+// buttons, optionally with a short label like "Share this:". Links to
+// social platforms which placed in the prose are kept, since their
+// parent has other text as well.
+func (ps *Parser) removeShareButtons(articleContent *html.Node) {
+	node := dom.FirstElementChild(articleContent)
+	for node != nil {
+		if ps.isShareButtonCluster(node) {
+			node = ps.removeAndGetNext(node)
+			continue
+		}
+		node = ps.getNextNode(node, false)
+	}
+}
+
+// isShareButtonCluster checks if node only contains share buttons.
+func (ps *Parser) isShareButtonCluster(node *html.Node) bool {
+	switch dom.TagName(node) {
+	case "a", "button", "img", "svg":
+		return false
+	}
+
+	if strings.HasPrefix(dom.ID(node), "readability") {
+		return false
+	}
+
+	buttons := ps.getAllNodesWithTag(node, "a", "button")
+	if len(buttons) == 0 {
+		return false
+	}
+
+	remainingText := ps.getInnerText(node, true)
+	for _, button := range buttons {
+		if !ps.isShareButton(button) {
+			return false
+		}
+		remainingText = strings.Replace(remainingText, ps.getInnerText(button, true), "", 1)
+	}
+
+	// Icons inside the buttons are fine, but not media outside them.
+	medias := ps.getAllNodesWithTag(node, "img", "picture", "video", "iframe")
+	for _, media := range medias {
+		if !ps.hasAncestorTag(media, "a", -1, nil) && !ps.hasAncestorTag(media, "button", -1, nil) {
+			return false
+		}
+	}
+
+	// The only text allowed beside the buttons is a short label,
+	// e.g. "Share this:".
+	remainingText = strings.TrimSpace(remainingText)
+	return remainingText == "" || (charCount(remainingText) <= 25 && rxShareButton.MatchString(remainingText))
+}
+
+// isShareButton checks if link or button is used for sharing the page,
+// either because it points to share endpoint of social platforms, or
+// because its label says so.
+func (ps *Parser) isShareButton(button *html.Node) bool {
+	href := strings.ToLower(dom.GetAttribute(button, "href"))
+	if rxShareURL.MatchString(href) {
+		return true
+	}
+
+	label := dom.GetAttribute(button, "aria-label") + " " +
+		dom.GetAttribute(button, "title") + " " +
+		dom.ClassName(button) + " " + dom.ID(button)
+	return rxShareButton.MatchString(label)
+}
@@ -0,0 +1,17 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_removeShareButtons(t *testing.T) {
+	// The share buttons are removed by default, as checked by the
+	// share-buttons test page.
+	parser := NewParser()
+	parser.RemoveShareButtons = false
+	article := parseTestPage(t, parser, "share-buttons")
+	if !strings.Contains(article.Content, "sharer.php") {
+		t.Errorf("\nwant share buttons kept when disabled, got %s", article.Content)
+	}
+}
@@ -0,0 +1,123 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// isSponsored checks whether the article is sponsored content. To avoid
+// false positives on articles which merely discuss sponsorship, and on
+// the sponsored widgets around the article, only these signals are used:
+//
+//   - JSON-LD declares the article as AdvertiserContentArticle, or it has
+//     sponsor property.
+//   - Meta article:sponsor or sponsored, whose content isn't "false".
+//   - Class or id of <html>, <body>, <main> or <article> says it's
+//     sponsored, advertorial, partner content or paid post.
+//   - Short label like "Sponsored", "Paid Post" or "In partnership with
+//     X" right after the title (<h1>), before the start of the article
+//     body.
+//
+// Since the labels are usually removed while the content grabbed, this
+// must be run before grabArticle.
+func (ps *Parser) isSponsored(jsonLd map[string]string) bool {
+	if jsonLd["type"] == "AdvertiserContentArticle" || jsonLd["sponsored"] == "true" {
+		return true
+	}
+
+	for _, meta := range dom.GetElementsByTagName(ps.doc, "meta") {
+		name := strings.ToLower(strOr(dom.GetAttribute(meta, "property"), dom.GetAttribute(meta, "name")))
+		content := strings.ToLower(strings.TrimSpace(dom.GetAttribute(meta, "content")))
+		if (name == "article:sponsor" || name == "sponsored") && content != "" && content != "false" {
+			return true
+		}
+	}
+
+	for _, node := range ps.getAllNodesWithTag(ps.doc, "html", "body", "main", "article") {
+		if rxSponsored.MatchString(dom.ClassName(node) + " " + dom.ID(node)) {
+			return true
+		}
+	}
+
+	return ps.hasSponsoredLabel()
+}
+
+// maxSponsoredLabelSiblings is the number of elements after the title
+// which checked for sponsored label.
+const maxSponsoredLabelSiblings = 3
+
+// hasSponsoredLabel looks for sponsored label in the header of article,
+// i.e. the few elements right after the title (<h1>) and after its
+// wrapper, until the first long text. The rest of document is never
+// checked, since the sponsored widgets might be anywhere around it.
+func (ps *Parser) hasSponsoredLabel() bool {
+	title := ps.getTitleHeading()
+	if title == nil {
+		return false
+	}
+
+	starts := []*html.Node{title}
+	if wrapper := title.Parent; wrapper != nil && charCount(ps.getInnerText(wrapper, true)) < 300 {
+		switch dom.TagName(wrapper) {
+		case "body", "main", "article", "section":
+		default:
+			starts = append(starts, wrapper)
+		}
+	}
+
+	for _, start := range starts {
+		sibling := dom.NextElementSibling(start)
+		for i := 0; sibling != nil && i < maxSponsoredLabelSiblings; i++ {
+			if charCount(ps.getInnerText(sibling, true)) >= 100 {
+				break
+			}
+
+			nodes := append([]*html.Node{sibling}, dom.GetElementsByTagName(sibling, "*")...)
+			for _, node := range nodes {
+				text := ps.getInnerText(node, true)
+				if charCount(text) <= 50 && rxSponsoredLabel.MatchString(text) {
+					return true
+				}
+			}
+
+			sibling = dom.NextElementSibling(sibling)
+		}
+	}
+
+	return false
+}
+
+// getTitleHeading returns the <h1> which contains the article title, so
+// the heading used as site logo isn't mistaken as the title. If the title
+// is unknown, the only <h1> in document is used.
+func (ps *Parser) getTitleHeading() *html.Node {
+	h1s := dom.GetElementsByTagName(ps.doc, "h1")
+	title := strings.ToLower(strings.Join(strings.Fields(ps.articleTitle), " "))
+	if title == "" {
+		if len(h1s) == 1 {
+			return h1s[0]
+		}
+		return nil
+	}
+
+	// The title might contain site name, e.g. "Title | Site".
+	titleParts := rxTitleSeparator.Split(title, -1)
+	for _, h1 := range h1s {
+		text := strings.ToLower(ps.getInnerText(h1, true))
+		if text == "" {
+			continue
+		}
+
+		if strings.Contains(text, title) || (strings.Contains(title, text) && charCount(text)*2 >= charCount(title)) {
+			return h1
+		}
+
+		if len(titleParts) > 1 && text == strings.TrimSpace(titleParts[0]) {
+			return h1
+		}
+	}
+
+	return nil
+}
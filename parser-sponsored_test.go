@@ -0,0 +1,50 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_isSponsored(t *testing.T) {
+	division := "<div>" + strings.Repeat(testSentence, 12) + "</div>"
+	scenarios := []struct {
+		name      string
+		head      string
+		body      string
+		sponsored bool
+	}{
+		{"normal article", ``,
+			`<article><h1>Title</h1>` + testParagraph + testParagraph + `</article>`, false},
+		{"article about sponsorship", ``,
+			`<article><h1>Title</h1><p>The team was sponsored by a bank, the sponsored athletes said.</p>` +
+				testParagraph + testParagraph + `</article>`, false},
+		{"sponsored widget in sidebar", ``,
+			`<aside class="sponsored">Sponsored</aside><article><h1>Title</h1>` + testParagraph + testParagraph + `</article>`, false},
+		{"sponsored widget with site logo as h1", `<title>Real Title - Daily News</title>`,
+			`<header><h1>Daily News</h1></header><aside><div>Sponsored</div><ul><li>Buy this</li></ul></aside>` +
+				`<article><h2>Real Title</h2>` + division + division + `</article>`, false},
+		{"sponsored widget after article without paragraphs", `<title>Title</title>`,
+			`<article><h1>Title</h1>` + division + division + `</article><aside><span>Sponsored</span></aside>`, false},
+		{"json-ld type", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"AdvertiserContentArticle"}</script>`,
+			`<article><h1>Title</h1>` + testParagraph + testParagraph + `</article>`, true},
+		{"json-ld sponsor", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","sponsor":{"@type":"Organization","name":"Acme"}}</script>`,
+			`<article><h1>Title</h1>` + testParagraph + testParagraph + `</article>`, true},
+		{"meta", `<meta property="article:sponsor" content="Acme">`,
+			`<article><h1>Title</h1>` + testParagraph + testParagraph + `</article>`, true},
+		{"article class", ``,
+			`<article class="post post-sponsored"><h1>Title</h1>` + testParagraph + testParagraph + `</article>`, true},
+		{"label near byline", ``,
+			`<article><h1>Title</h1><div class="byline">By Jane Doe</div><span>Paid Post</span>` + testParagraph + testParagraph + `</article>`, true},
+		{"label after title wrapper", `<title>Title | Daily News</title>`,
+			`<article><header><h1>Title</h1></header><div class="meta"><span>By Jane Doe</span> <span>Sponsored</span></div>` +
+				testParagraph + testParagraph + `</article>`, true},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head>` + scenario.head + `</head><body>` + scenario.body + `</body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Sponsored != scenario.sponsored {
+			t.Errorf("\n%s: want sponsored %v, got %v", scenario.name, scenario.sponsored, article.Sponsored)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package readability
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// srcsetCandidate is the image candidate declared in srcset.
+type srcsetCandidate struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// resolveSrcsets sets the src of every image in article content into
+// the best candidate of its srcset or data-srcset, i.e. the one with the
+// largest width, or the largest pixel density if the widths are not
+// declared. Unless KeepSrcset is enabled, the srcset is removed
+// afterward so the image is rendered from the resolved src.
+func (ps *Parser) resolveSrcsets(articleContent *html.Node) {
+	for _, img := range dom.GetElementsByTagName(articleContent, "img") {
+		srcset := strOr(dom.GetAttribute(img, "srcset"), dom.GetAttribute(img, "data-srcset"))
+		if best := getBestSrcsetCandidate(srcset); best != "" {
+			dom.SetAttribute(img, "src", toAbsoluteURI(best, ps.documentURI))
+		}
+
+		if !ps.KeepSrcset {
+			dom.RemoveAttribute(img, "srcset")
+			dom.RemoveAttribute(img, "data-srcset")
+		}
+	}
+}
+
+// getBestSrcsetCandidate returns the URL of the largest candidate in
+// srcset. Returns empty string if there are no valid candidates.
+func getBestSrcsetCandidate(srcset string) string {
+	candidates := parseSrcset(srcset)
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		switch {
+		case candidate.Width > 0 || best.Width > 0:
+			// Width descriptor is more reliable than density.
+			if candidate.Width > best.Width {
+				best = candidate
+			}
+		case candidate.Density > best.Density:
+			best = candidate
+		}
+	}
+
+	return best.URL
+}
+
+// parseSrcset parses the candidates in srcset, following the way browser
+// splits them: the URL is separated from its descriptors by whitespace,
+// and the candidates are separated by comma. Since comma might be used
+// inside URL (e.g. Cloudinary transformation), the trailing commas of URL
+// ends the candidate only if it's not followed by descriptors. Candidates
+// with malformed descriptor are skipped.
+func parseSrcset(srcset string) []srcsetCandidate {
+	var candidates []srcsetCandidate
+	for rest := srcset; ; {
+		rest = strings.TrimLeftFunc(rest, func(r rune) bool {
+			return unicode.IsSpace(r) || r == ','
+		})
+		if rest == "" {
+			break
+		}
+
+		// Extract the URL.
+		urlEnd := strings.IndexFunc(rest, unicode.IsSpace)
+		if urlEnd < 0 {
+			urlEnd = len(rest)
+		}
+
+		url := rest[:urlEnd]
+		rest = rest[urlEnd:]
+
+		// Extract the descriptors, unless URL is ended by comma.
+		var descriptors []string
+		if trimmedURL := strings.TrimRight(url, ","); trimmedURL != url {
+			url = trimmedURL
+		} else {
+			descriptorEnd := strings.Index(rest, ",")
+			if descriptorEnd < 0 {
+				descriptorEnd = len(rest)
+			}
+
+			descriptors = strings.Fields(rest[:descriptorEnd])
+			rest = rest[descriptorEnd:]
+		}
+
+		if candidate, valid := newSrcsetCandidate(url, descriptors); valid {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+// newSrcsetCandidate creates srcset candidate from its URL and
+// descriptors. Candidate without descriptor has density 1x.
+func newSrcsetCandidate(url string, descriptors []string) (srcsetCandidate, bool) {
+	candidate := srcsetCandidate{URL: url}
+	if url == "" {
+		return candidate, false
+	}
+
+	for _, descriptor := range descriptors {
+		value := descriptor[:len(descriptor)-1]
+		switch strings.ToLower(descriptor[len(descriptor)-1:]) {
+		case "w":
+			width, err := strconv.Atoi(value)
+			if err != nil || width <= 0 || candidate.Width > 0 || candidate.Density > 0 {
+				return candidate, false
+			}
+			candidate.Width = width
+		case "x":
+			density, err := strconv.ParseFloat(value, 64)
+			if err != nil || density <= 0 || candidate.Width > 0 || candidate.Density > 0 {
+				return candidate, false
+			}
+			candidate.Density = density
+		case "h":
+			// Future-compat height descriptor, not used for choosing.
+			if _, err := strconv.Atoi(value); err != nil {
+				return candidate, false
+			}
+		default:
+			return candidate, false
+		}
+	}
+
+	if candidate.Width == 0 && candidate.Density == 0 {
+		candidate.Density = 1
+	}
+	return candidate, true
+}
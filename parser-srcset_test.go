@@ -0,0 +1,70 @@
+package readability
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+)
+
+func Test_resolveSrcsets(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		img      string
+		expected string
+	}{{
+		name:     "width descriptors",
+		img:      `<img src="small.jpg" srcset="small.jpg 320w, large.jpg 1280w, medium.jpg 640w">`,
+		expected: "http://fakehost/test/large.jpg",
+	}, {
+		name:     "density descriptors",
+		img:      `<img src="photo.jpg" srcset="photo.jpg, photo@3x.jpg 3x, photo@2x.jpg 2x">`,
+		expected: "http://fakehost/test/photo@3x.jpg",
+	}, {
+		name:     "data-srcset",
+		img:      `<img src="small.jpg" data-srcset="/img/small.jpg 320w, /img/large.jpg 1280w">`,
+		expected: "http://fakehost/img/large.jpg",
+	}, {
+		name:     "comma inside URL",
+		img:      `<img src="small.jpg" srcset="https://cdn.test/w_320,h_200/a.jpg 320w, https://cdn.test/w_960,h_600/a.jpg 960w">`,
+		expected: "https://cdn.test/w_960,h_600/a.jpg",
+	}, {
+		name:     "malformed entries",
+		img:      `<img src="small.jpg" srcset="huge.jpg 2y, broken.jpg wide, medium.jpg 640w, , bad.jpg -5w">`,
+		expected: "http://fakehost/test/medium.jpg",
+	}, {
+		name:     "no valid candidate",
+		img:      `<img src="small.jpg" srcset="huge.jpg 2y">`,
+		expected: "http://fakehost/test/small.jpg",
+	}}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.ResolveSrcset = true
+
+		rawHTML := `<html><body><article>` + testParagraph + scenario.img + testParagraph + `</article></body></html>`
+		article := parseHTMLString(t, parser, rawHTML)
+		images := dom.GetElementsByTagName(article.Node, "img")
+		if len(images) != 1 {
+			t.Errorf("\n%s: want 1 image, got %s", scenario.name, article.Content)
+			continue
+		}
+
+		if src := dom.GetAttribute(images[0], "src"); src != scenario.expected {
+			t.Errorf("\n%s: want src %q, got %q", scenario.name, scenario.expected, src)
+		}
+
+		if dom.HasAttribute(images[0], "srcset") || dom.HasAttribute(images[0], "data-srcset") {
+			t.Errorf("\n%s: want srcset to be removed, got %s", scenario.name, dom.OuterHTML(images[0]))
+		}
+	}
+
+	parser := NewParser()
+	parser.ResolveSrcset = true
+	parser.KeepSrcset = true
+	rawHTML := `<html><body><article>` + testParagraph + scenarios[0].img + testParagraph + `</article></body></html>`
+	article := parseHTMLString(t, parser, rawHTML)
+	images := dom.GetElementsByTagName(article.Node, "img")
+	if len(images) != 1 || !dom.HasAttribute(images[0], "srcset") {
+		t.Errorf("\nwant srcset to be kept, got %s", article.Content)
+	}
+}
@@ -0,0 +1,204 @@
+package readability
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// Settings of TextRank used for extracting summary sentences.
+const (
+	summaryDampingFactor = 0.85
+	summaryMaxIterations = 50
+	summaryConvergence   = 0.0001
+	summaryMinWords      = 5
+	summaryMaxSentences  = 300
+)
+
+// summaryStopWords is common English words which ignored while
+// measuring the similarity between sentences.
+var summaryStopWords = sliceToMap(
+	"a", "about", "after", "all", "also", "an", "and", "any", "are", "as", "at",
+	"be", "been", "but", "by", "can", "could", "did", "do", "does", "for", "from",
+	"had", "has", "have", "he", "her", "his", "how", "i", "if", "in", "into", "is",
+	"it", "its", "just", "more", "most", "my", "no", "not", "of", "on", "one", "or",
+	"our", "out", "she", "so", "some", "than", "that", "the", "their", "them",
+	"then", "there", "these", "they", "this", "to", "up", "was", "we", "were",
+	"what", "when", "which", "who", "will", "with", "would", "you", "your")
+
+// getSummarySentences returns the n sentences that represent the article
+// content the most, using TextRank: every sentence is voted by other
+// sentences that share words with it. The sentences are returned in the
+// order they appear in the content. Since the stop words and sentence
+// splitting are tuned for English, it only runs when the document is in
+// English or doesn't declare its language.
+func (ps *Parser) getSummarySentences(articleContent *html.Node, n int) []string {
+	if lang := baseLanguage(ps.getDocumentLanguage()); lang != "" && lang != "en" {
+		return nil
+	}
+
+	// Text content of the whole article doesn't separate the paragraphs,
+	// so the sentences are taken paragraph by paragraph.
+	var text string
+	if paragraphs := ps.getAllNodesWithTag(articleContent, "p"); len(paragraphs) > 0 {
+		texts := make([]string, len(paragraphs))
+		for i, paragraph := range paragraphs {
+			texts[i] = ps.getInnerText(paragraph, true)
+		}
+		text = strings.Join(texts, "\n")
+	} else {
+		text = ps.getTextContent(articleContent)
+	}
+
+	if ps.RepairMojibake {
+		text = repairMojibake(text)
+	}
+
+	var sentences []string
+	var sentenceWords []map[string]struct{}
+	seen := make(map[string]struct{})
+	for _, sentence := range splitSentences(text) {
+		// Repeated sentences would vote for each other, so skip them.
+		if _, isSeen := seen[sentence]; isSeen {
+			continue
+		}
+		seen[sentence] = struct{}{}
+
+		words := summaryWords(sentence)
+		if len(words) < summaryMinWords {
+			continue
+		}
+
+		sentences = append(sentences, sentence)
+		sentenceWords = append(sentenceWords, words)
+		if len(sentences) >= summaryMaxSentences {
+			break
+		}
+	}
+
+	if len(sentences) <= n {
+		return sentences
+	}
+
+	// Build the similarity graph between sentences.
+	nSentences := len(sentences)
+	similarities := make([][]float64, nSentences)
+	weightSums := make([]float64, nSentences)
+	for i := range similarities {
+		similarities[i] = make([]float64, nSentences)
+	}
+
+	for i := 0; i < nSentences; i++ {
+		for j := i + 1; j < nSentences; j++ {
+			overlap := 0
+			for word := range sentenceWords[i] {
+				if _, exist := sentenceWords[j][word]; exist {
+					overlap++
+				}
+			}
+
+			if overlap == 0 {
+				continue
+			}
+
+			similarity := float64(overlap) / (math.Log(float64(len(sentenceWords[i]))) + math.Log(float64(len(sentenceWords[j]))))
+			similarities[i][j], similarities[j][i] = similarity, similarity
+			weightSums[i] += similarity
+			weightSums[j] += similarity
+		}
+	}
+
+	// Rank the sentences until the scores converge.
+	scores := make([]float64, nSentences)
+	for i := range scores {
+		scores[i] = 1
+	}
+
+	for iteration := 0; iteration < summaryMaxIterations; iteration++ {
+		maxDelta := 0.0
+		newScores := make([]float64, nSentences)
+		for i := 0; i < nSentences; i++ {
+			rank := 0.0
+			for j := 0; j < nSentences; j++ {
+				if similarities[j][i] > 0 && weightSums[j] > 0 {
+					rank += similarities[j][i] / weightSums[j] * scores[j]
+				}
+			}
+
+			newScores[i] = (1 - summaryDampingFactor) + summaryDampingFactor*rank
+			maxDelta = math.Max(maxDelta, math.Abs(newScores[i]-scores[i]))
+		}
+
+		scores = newScores
+		if maxDelta < summaryConvergence {
+			break
+		}
+	}
+
+	// Pick the best sentences, then restore their original order.
+	indexes := make([]int, nSentences)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return scores[indexes[a]] > scores[indexes[b]]
+	})
+
+	indexes = indexes[:n]
+	sort.Ints(indexes)
+
+	summary := make([]string, len(indexes))
+	for i, idx := range indexes {
+		summary[i] = sentences[idx]
+	}
+
+	return summary
+}
+
+// splitSentences splits text into sentences. Sentence ends at line
+// break, or at ".", "!" and "?" which followed by space and a char
+// that commonly starts a sentence.
+func splitSentences(text string) []string {
+	var sentences []string
+	for _, line := range strings.Split(text, "\n") {
+		runes := []rune(strings.TrimSpace(line))
+		start := 0
+		for i := 0; i < len(runes); i++ {
+			if runes[i] != '.' && runes[i] != '!' && runes[i] != '?' {
+				continue
+			}
+
+			if i+2 < len(runes) && unicode.IsSpace(runes[i+1]) &&
+				(unicode.IsUpper(runes[i+2]) || unicode.IsDigit(runes[i+2]) || strings.ContainsRune(`"'“‘(`, runes[i+2])) {
+				sentences = append(sentences, strings.TrimSpace(string(runes[start:i+1])))
+				start = i + 2
+			}
+		}
+
+		if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+			sentences = append(sentences, rest)
+		}
+	}
+
+	return sentences
+}
+
+// summaryWords returns the unique lowercased words in sentence,
+// except the stop words.
+func summaryWords(sentence string) map[string]struct{} {
+	words := make(map[string]struct{})
+	for _, word := range strings.FieldsFunc(strings.ToLower(sentence), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\''
+	}) {
+		word = strings.Trim(word, "'")
+		if _, isStopWord := summaryStopWords[word]; word != "" && !isStopWord {
+			words[word] = struct{}{}
+		}
+	}
+
+	return words
+}
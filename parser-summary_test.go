@@ -0,0 +1,59 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_splitSentences(t *testing.T) {
+	text := "Dr. smith arrived at 5 p.m. on Monday. He said \"hello\" to everyone! Did they answer? Yes.\nA new line starts here"
+	expected := []string{
+		"Dr. smith arrived at 5 p.m. on Monday.",
+		`He said "hello" to everyone!`,
+		"Did they answer?",
+		"Yes.",
+		"A new line starts here",
+	}
+
+	if result := splitSentences(text); !reflect.DeepEqual(result, expected) {
+		t.Errorf("\n"+
+			"want : %q\n"+
+			"got  : %q", expected, result)
+	}
+}
+
+func Test_getSummarySentences(t *testing.T) {
+	paragraphs := []string{
+		"The city council approved the new budget for public transit on Tuesday evening.",
+		"The transit budget adds new bus routes and extends the subway hours across the city.",
+		"My neighbor bought a red bicycle with a wicker basket last spring.",
+		"Council members said the transit budget will be funded by a small increase in parking fees.",
+		"Critics of the budget argued the parking fees would hurt small businesses in the city.",
+		"Lunch was served with sandwiches, lemonade and cookies for everyone present.",
+		"The weather forecast predicts heavy rain and strong wind for the coming weekend.",
+		"A local bakery won first prize at the regional pastry competition in April.",
+	}
+
+	rawHTML := `<html lang="en-US"><body><article><p>` + strings.Join(paragraphs, "</p><p>") + `</p></article></body></html>`
+
+	parser := NewParser()
+	parser.ExtractSummary = 2
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if len(article.SummarySentences) != 2 {
+		t.Fatalf("\nwant 2 sentences, got %q", article.SummarySentences)
+	}
+
+	for _, sentence := range article.SummarySentences {
+		if !strings.Contains(strings.ToLower(sentence), "budget") {
+			t.Errorf("\nwant sentences about budget, got %q", article.SummarySentences)
+		}
+	}
+
+	// Non-English document is skipped.
+	rawHTML = strings.Replace(rawHTML, `lang="en-US"`, `lang="de"`, 1)
+	if article := parseHTMLString(t, parser, rawHTML); article.SummarySentences != nil {
+		t.Errorf("\nwant no summary for non-English document, got %q", article.SummarySentences)
+	}
+}
@@ -0,0 +1,40 @@
+package readability
+
+import (
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// unwrapLayoutTables replaces the layout tables in article content with
+// the content of their cells, so only the data tables keep the table
+// structure. The tables must be marked by markDataTables before. The
+// inner tables are unwrapped first, so the cells of nested layout tables
+// end up in the same order as they are read.
+func (ps *Parser) unwrapLayoutTables(articleContent *html.Node) {
+	tables := dom.GetElementsByTagName(articleContent, "table")
+	for i := len(tables) - 1; i >= 0; i-- {
+		table := tables[i]
+		if table.Parent == nil || ps.isReadabilityDataTable(table) {
+			continue
+		}
+
+		for _, cell := range dom.GetElementsByTagName(table, "*") {
+			if tagName := dom.TagName(cell); tagName != "td" && tagName != "th" {
+				continue
+			}
+
+			// Skip the cells of data table nested in this table.
+			if closestTable(cell) != table || ps.isElementWithoutContent(cell) {
+				continue
+			}
+
+			div := dom.CreateElement("div")
+			for cell.FirstChild != nil {
+				dom.AppendChild(div, cell.FirstChild)
+			}
+			table.Parent.InsertBefore(div, table)
+		}
+
+		table.Parent.RemoveChild(table)
+	}
+}
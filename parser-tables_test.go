@@ -0,0 +1,39 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_unwrapLayoutTables(t *testing.T) {
+	dataTable := `<table><thead><tr><th>Year</th><th>Sales</th></tr></thead>` +
+		`<tbody><tr><td>2021</td><td>10</td></tr><tr><td>2022</td><td>12</td></tr></tbody></table>`
+	layoutTable := `<table><tr><td><p>Layout cell paragraph which is part of the article text.</p></td>` +
+		`<td><img src="photo.jpg"></td><td></td></tr></table>`
+	rawHTML := `<html><body><article>` + testParagraph + dataTable + testParagraph + layoutTable + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.UnwrapLayoutTables = true
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if count := strings.Count(article.Content, "<table"); count != 1 {
+		t.Errorf("want only the data table kept, got %d tables:\n%s", count, article.Content)
+	}
+
+	for _, want := range []string{
+		"<thead><tr><th>Year</th><th>Sales</th></tr></thead>",
+		"<tr><td>2022</td><td>12</td></tr>",
+		"Layout cell paragraph",
+		`<img src="http://fakehost/test/photo.jpg"/>`,
+	} {
+		if !strings.Contains(article.Content, want) {
+			t.Errorf("want %q in content:\n%s", want, article.Content)
+		}
+	}
+
+	// Layout tables are kept by default, like Readability.js does.
+	article = parseHTMLString(t, NewParser(), rawHTML)
+	if count := strings.Count(article.Content, "<table"); count != 2 {
+		t.Errorf("want both tables kept by default, got %d tables:\n%s", count, article.Content)
+	}
+}
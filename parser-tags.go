@@ -0,0 +1,35 @@
+package readability
+
+import (
+	shtml "html"
+	"strings"
+)
+
+// getTags merges the comma separated tags from the sources, in order.
+// The tags are deduplicated case-insensitively, keeping the form of the
+// first occurrence.
+func getTags(sources ...string) []string {
+	var tags []string
+	seen := make(map[string]struct{})
+	for _, source := range sources {
+		for _, tag := range strings.Split(source, ",") {
+			tag = strings.Join(strings.Fields(shtml.UnescapeString(tag)), " ")
+			key := strings.ToLower(tag)
+			if _, isSeen := seen[key]; tag == "" || isSeen {
+				continue
+			}
+
+			seen[key] = struct{}{}
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// splitTags splits the tags in metadata which joined by newline.
+func splitTags(str string) []string {
+	if str == "" {
+		return nil
+	}
+	return strings.Split(str, "\n")
+}
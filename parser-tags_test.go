@@ -0,0 +1,36 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_getTags(t *testing.T) {
+	scenarios := []struct {
+		name string
+		head string
+		tags []string
+	}{
+		{"json-ld string", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+			`"keywords":"Climate, Energy ,  Solar power"}</script>`,
+			[]string{"Climate", "Energy", "Solar power"}},
+		{"json-ld array", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+			`"keywords":["Climate","Energy","Solar power"]}</script>`,
+			[]string{"Climate", "Energy", "Solar power"}},
+		{"merged and deduplicated", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+			`"keywords":["Climate","Energy"]}</script>` +
+			`<meta property="article:tag" content="energy">` +
+			`<meta property="article:tag" content="Wind">` +
+			`<meta name="keywords" content="climate, WIND, Policy">`,
+			[]string{"Climate", "Energy", "Wind", "Policy"}},
+		{"none", ``, nil},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := articleHTML(scenario.head, "")
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if strings.Join(article.Tags, "|") != strings.Join(scenario.tags, "|") {
+			t.Errorf("\n%s: want tags %q, got %q", scenario.name, scenario.tags, article.Tags)
+		}
+	}
+}
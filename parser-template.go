@@ -0,0 +1,63 @@
+package readability
+
+import (
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// hoistTemplateContent looks for the largest <template> whose content
+// is substantial (at least CharThresholds chars), and moves its content
+// into the live document as a <div>, so it can be scored by grabArticle.
+// The other templates are removed, since they're inert and would only
+// compete with the hoisted content. Templates for declarative shadow DOM
+// are left as they are.
+func (ps *Parser) hoistTemplateContent() {
+	var body *html.Node
+	if bodies := dom.GetElementsByTagName(ps.doc, "body"); len(bodies) > 0 {
+		body = bodies[0]
+	}
+
+	if body == nil {
+		return
+	}
+
+	var templates []*html.Node
+	var bestTemplate *html.Node
+	bestLength := 0
+	for _, template := range dom.GetElementsByTagName(ps.doc, "template") {
+		if dom.HasAttribute(template, "shadowrootmode") || dom.HasAttribute(template, "shadowroot") {
+			continue
+		}
+
+		templates = append(templates, template)
+		textLength := charCount(ps.getInnerText(template, true))
+		if textLength >= ps.CharThresholds && textLength > bestLength {
+			bestTemplate, bestLength = template, textLength
+		}
+	}
+
+	if bestTemplate == nil {
+		return
+	}
+
+	// Remove the other templates, including the ones nested inside the
+	// best template, before its content is moved.
+	ps.removeNodes(templates, func(template *html.Node) bool {
+		return template != bestTemplate
+	})
+
+	container := dom.CreateElement("div")
+	for child := bestTemplate.FirstChild; child != nil; child = bestTemplate.FirstChild {
+		bestTemplate.RemoveChild(child)
+		container.AppendChild(child)
+	}
+
+	// Template in head can't simply be replaced, since its content isn't
+	// allowed there, so it's appended into body instead.
+	if isAncestor(body, bestTemplate) {
+		bestTemplate.Parent.InsertBefore(container, bestTemplate)
+	} else {
+		body.AppendChild(container)
+	}
+	bestTemplate.Parent.RemoveChild(bestTemplate)
+}
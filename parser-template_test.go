@@ -0,0 +1,35 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func Test_hoistTemplateContent(t *testing.T) {
+	paragraph := func(word string) string {
+		return "<p>" + strings.Repeat("The "+word+" story goes on and on, with many details. ", 12) + "</p>"
+	}
+
+	rawHTML := `<html><head><title>Test</title></head><body><div id="app">Loading...</div>` +
+		`<template id="small">` + paragraph("teaser") + `</template>` +
+		`<template id="hydrate"><article>` + paragraph("real") + paragraph("real") + `</article></template>` +
+		`</body></html>`
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	parser := NewParser()
+	parser.MinVisibleTextLength = 25
+	if _, err := parser.Parse(strings.NewReader(rawHTML), pageURL); err != ErrNoContent {
+		t.Errorf("\nwant ErrNoContent without UseTemplateContent, got %v", err)
+	}
+
+	parser.UseTemplateContent = true
+	article := parseHTMLString(t, parser, rawHTML)
+	if !strings.Contains(article.TextContent, "The real story") {
+		t.Errorf("\nwant content from the largest template, got %.80q", article.TextContent)
+	}
+
+	if strings.Contains(article.TextContent, "teaser") {
+		t.Errorf("\nwant smaller template to be ignored, got %.80q", article.TextContent)
+	}
+}
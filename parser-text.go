@@ -0,0 +1,109 @@
+package readability
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// textBuilder builds the text content of a node, while keeping the words
+// in separate blocks from being glued together.
+type textBuilder struct {
+	buf []byte
+	// spaceAt is the length of text right after the space written at
+	// block boundary, or -1 if the text doesn't end with such space.
+	spaceAt int
+}
+
+// WriteString appends str to the text. If the text ends with the space
+// of block boundary and str starts with its own whitespace, the boundary
+// space is dropped so the whitespace isn't doubled. The length of text
+// never gets shorter, so the offsets taken from it stay valid.
+func (tb *textBuilder) WriteString(str string) {
+	if str == "" {
+		return
+	}
+
+	if firstRune, _ := utf8.DecodeRuneInString(str); tb.spaceAt == len(tb.buf) && unicode.IsSpace(firstRune) {
+		tb.buf = tb.buf[:len(tb.buf)-1]
+	}
+
+	tb.buf = append(tb.buf, str...)
+	tb.spaceAt = -1
+}
+
+// writeBoundary marks the boundary of block element. A single space is
+// written, unless the text is empty or already ends with whitespace.
+func (tb *textBuilder) writeBoundary() {
+	if len(tb.buf) == 0 {
+		return
+	}
+
+	if lastRune, _ := utf8.DecodeLastRune(tb.buf); !unicode.IsSpace(lastRune) {
+		tb.buf = append(tb.buf, ' ')
+		tb.spaceAt = len(tb.buf)
+	}
+}
+
+// Len returns the length of text in bytes.
+func (tb *textBuilder) Len() int {
+	return len(tb.buf)
+}
+
+// String returns the text.
+func (tb *textBuilder) String() string {
+	return string(tb.buf)
+}
+
+// buildTextContent returns the text content of root. Unlike
+// dom.TextContent, the block elements and <br> are separated by space,
+// while the inline fragments of the same word (e.g. "end<a>of</a>line")
+// are still joined as they are. If visit is specified, it's called when
+// entering and leaving each element, so the caller can use the current
+// length of text as offset.
+func (ps *Parser) buildTextContent(root *html.Node, visit func(node *html.Node, tb *textBuilder, entering bool)) string {
+	tb := textBuilder{spaceAt: -1}
+	var walker func(*html.Node)
+
+	walker = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			tb.WriteString(node.Data)
+			return
+		}
+
+		tagName := dom.TagName(node)
+		_, isBlock := blockElems[tagName]
+		isBoundary := node.Type == html.ElementNode && (isBlock || tagName == "br")
+
+		if isBoundary {
+			tb.writeBoundary()
+		}
+
+		if visit != nil && node.Type == html.ElementNode {
+			visit(node, &tb, true)
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walker(child)
+		}
+
+		if visit != nil && node.Type == html.ElementNode {
+			visit(node, &tb, false)
+		}
+
+		if isBoundary {
+			tb.writeBoundary()
+		}
+	}
+
+	walker(root)
+	return tb.String()
+}
+
+// getTextContent returns the text content of node, with the blocks
+// separated by space.
+func (ps *Parser) getTextContent(node *html.Node) string {
+	return ps.buildTextContent(node, nil)
+}
@@ -0,0 +1,36 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-shiori/dom"
+)
+
+func Test_getTextContent(t *testing.T) {
+	scenarios := map[string]string{
+		// Inline fragments of the same word are joined.
+		`<div>end<a href="#">of</a>line</div>`:                    "endofline",
+		`<div><span>adj</span><span>acent</span> spans</div>`:     "adjacent spans",
+		`<div>a link <a href="#">in the</a> middle of text</div>`: "a link in the middle of text",
+		`<div><b>Bold</b><i>Italic</i></div>`:                     "BoldItalic",
+		// Blocks are separated by a single space.
+		`<div><p>First paragraph.</p><p>Second paragraph.</p></div>`: "First paragraph. Second paragraph.",
+		`<div><h2>Title</h2><ul><li>One</li><li>Two</li></ul></div>`: "Title One Two",
+		`<div>Line one<br>line two</div>`:                            "Line one line two",
+		// Existing whitespace is not doubled.
+		"<div><p>First.</p>\n<p>Second.</p></div>": "First.\nSecond.",
+	}
+
+	parser := NewParser()
+	for rawHTML, expected := range scenarios {
+		doc, _ := dom.Parse(strings.NewReader(rawHTML))
+		div := dom.GetElementsByTagName(doc, "div")[0]
+		if result := strings.TrimSpace(parser.getTextContent(div)); result != expected {
+			t.Errorf("\n"+
+				"html : %s\n"+
+				"want : %q\n"+
+				"got  : %q", rawHTML, expected, result)
+		}
+	}
+}
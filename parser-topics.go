@@ -0,0 +1,61 @@
+package readability
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Limits of the topics extracted from link anchor texts.
+const (
+	maxTopics         = 20
+	maxTopicWordCount = 6
+)
+
+// getTopics collects the anchor texts of links in article content as
+// topics. Navigation links have been removed while the content grabbed,
+// but in-page links, generic texts like "click here" or "[1]", and
+// texts that too long to be an entity are skipped as well. The topics
+// are deduplicated case-insensitively, then ranked by how often they're
+// linked, and by their first appearance for the same frequency.
+func (ps *Parser) getTopics(articleContent *html.Node) []string {
+	var topics []string
+	counts := make(map[string]int)
+	texts := make(map[string]string)
+
+	for _, link := range dom.GetElementsByTagName(articleContent, "a") {
+		href := strings.TrimSpace(dom.GetAttribute(link, "href"))
+		if href == "" || strings.HasPrefix(href, "#") {
+			continue
+		}
+
+		text := ps.getInnerText(link, true)
+		nWords := wordCount(text)
+		if charCount(text) < 2 || nWords > maxTopicWordCount || rxGenericLinkText.MatchString(text) {
+			continue
+		}
+
+		key := strings.ToLower(text)
+		if _, exist := counts[key]; !exist {
+			topics = append(topics, key)
+			texts[key] = text
+		}
+		counts[key]++
+	}
+
+	sort.SliceStable(topics, func(i, j int) bool {
+		return counts[topics[i]] > counts[topics[j]]
+	})
+
+	if len(topics) > maxTopics {
+		topics = topics[:maxTopics]
+	}
+
+	for i, key := range topics {
+		topics[i] = texts[key]
+	}
+
+	return topics
+}
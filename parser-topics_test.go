@@ -0,0 +1,33 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_getTopics(t *testing.T) {
+	filler := strings.Repeat(testSentence, 6)
+	rawHTML := `<html><body><article>` +
+		`<p>The <a href="/wiki/Go">Go</a> language was designed at <a href="/wiki/Google">Google</a>. ` + filler + `</p>` +
+		`<p>Unlike <a href="/wiki/Rust">Rust</a>, <a href="/wiki/Go">go</a> has garbage collection. ` + filler + `</p>` +
+		`<p>See <a href="/notes">here</a> and <a href="#cite-1">[1]</a>. Also <a href="/wiki/Google">Google</a> ` +
+		`uses <a href="/wiki/Go">Go</a> heavily. ` + filler + `</p>` +
+		`</article></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	if article.Topics != nil {
+		t.Errorf("\nwant no topics by default, got %q", article.Topics)
+	}
+
+	parser.ExtractTopics = true
+	article = parseHTMLString(t, parser, rawHTML)
+
+	expected := []string{"Go", "Google", "Rust"}
+	if !reflect.DeepEqual(article.Topics, expected) {
+		t.Errorf("\n"+
+			"want : %q\n"+
+			"got  : %q", expected, article.Topics)
+	}
+}
@@ -0,0 +1,81 @@
+package readability
+
+import (
+	nurl "net/url"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// defaultTrackingParams is the query parameters which removed from the
+// links by default when StripTrackingParams is enabled. Name which ends
+// with "*" matches every parameter with that prefix.
+var defaultTrackingParams = []string{
+	"utm_*", "fbclid", "gclid", "dclid", "gbraid", "wbraid", "msclkid", "yclid",
+	"mc_cid", "mc_eid", "igshid", "_hsenc", "_hsmi", "mkt_tok", "oly_anon_id", "oly_enc_id",
+}
+
+// stripTrackingParams removes the tracking parameters from the href of
+// links in article content. The rest of URL, including the order of the
+// remaining parameters and the fragment, is kept as it is.
+func (ps *Parser) stripTrackingParams(articleContent *html.Node) {
+	for _, link := range dom.GetElementsByTagName(articleContent, "a") {
+		href := dom.GetAttribute(link, "href")
+		if stripped := ps.stripTrackingParamsFromURL(href); stripped != href {
+			dom.SetAttribute(link, "href", stripped)
+		}
+	}
+}
+
+// stripTrackingParamsFromURL returns the URL without the parameters
+// listed in TrackingParams. The "?" is removed as well if there are no
+// parameters left.
+func (ps *Parser) stripTrackingParamsFromURL(url string) string {
+	queryStart := strings.Index(url, "?")
+	if queryStart < 0 {
+		return url
+	}
+
+	base, query, fragment := url[:queryStart], url[queryStart+1:], ""
+	if fragmentStart := strings.Index(query, "#"); fragmentStart >= 0 {
+		query, fragment = query[:fragmentStart], query[fragmentStart:]
+	}
+
+	var kept []string
+	for _, param := range strings.Split(query, "&") {
+		if param == "" {
+			continue
+		}
+
+		key := strings.SplitN(param, "=", 2)[0]
+		if unescaped, err := nurl.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+
+		if !ps.isTrackingParam(key) {
+			kept = append(kept, param)
+		}
+	}
+
+	if len(kept) == 0 {
+		return base + fragment
+	}
+	return base + "?" + strings.Join(kept, "&") + fragment
+}
+
+// isTrackingParam determines if the query key is listed in TrackingParams.
+func (ps *Parser) isTrackingParam(key string) bool {
+	key = strings.ToLower(key)
+	for _, param := range ps.TrackingParams {
+		param = strings.ToLower(param)
+		if strings.HasSuffix(param, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(param, "*")) {
+				return true
+			}
+		} else if key == param {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package readability
+
+import (
+	"testing"
+
+	"github.com/go-shiori/dom"
+)
+
+func Test_stripTrackingParamsFromURL(t *testing.T) {
+	scenarios := map[string]string{
+		"https://example.com/a?id=5&utm_source=x&page=2&fbclid=abc": "https://example.com/a?id=5&page=2",
+		"https://example.com/a?utm_source=x&utm_medium=y":           "https://example.com/a",
+		"https://example.com/a?utm_source=x#comments":               "https://example.com/a#comments",
+		"https://example.com/a?q=go%20lang&GCLID=1#top":             "https://example.com/a?q=go%20lang#top",
+		"https://example.com/a?utm%5Fsource=x&b=2":                  "https://example.com/a?b=2",
+		"https://example.com/a?id=5&&utm_campaign=":                 "https://example.com/a?id=5",
+		"https://example.com/a?futm_source=1&clid=2":                "https://example.com/a?futm_source=1&clid=2",
+		"https://example.com/a#utm_source=x":                        "https://example.com/a#utm_source=x",
+		"https://example.com/a":                                     "https://example.com/a",
+	}
+
+	parser := NewParser()
+	for url, expected := range scenarios {
+		if got := parser.stripTrackingParamsFromURL(url); got != expected {
+			t.Errorf("\n%s: want %s, got %s", url, expected, got)
+		}
+	}
+
+	parser.TrackingParams = append(parser.TrackingParams, "ref", "sr_*")
+	url := "https://example.com/a?ref=home&sr_share=1&id=5&utm_source=x"
+	if got := parser.stripTrackingParamsFromURL(url); got != "https://example.com/a?id=5" {
+		t.Errorf("\nwant custom params stripped, got %s", got)
+	}
+}
+
+func Test_stripTrackingParams(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p>Read <a href="/story?id=7&utm_source=newsletter&utm_medium=email&fbclid=xyz#part-2">the story</a>.</p>` +
+		testParagraph + `</article></body></html>`
+
+	scenarios := map[bool]string{
+		false: "http://fakehost/story?id=7&utm_source=newsletter&utm_medium=email&fbclid=xyz#part-2",
+		true:  "http://fakehost/story?id=7#part-2",
+	}
+
+	for strip, expected := range scenarios {
+		parser := NewParser()
+		parser.StripTrackingParams = strip
+		article := parseHTMLString(t, parser, rawHTML)
+
+		links := dom.GetElementsByTagName(article.Node, "a")
+		if len(links) != 1 {
+			t.Fatalf("\nwant 1 link, got %d", len(links))
+		}
+
+		if href := dom.GetAttribute(links[0], "href"); href != expected {
+			t.Errorf("\nstrip %v: want %s, got %s", strip, expected, href)
+		}
+	}
+}
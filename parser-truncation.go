@@ -0,0 +1,30 @@
+package readability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// truncatedWordCountRatio is the fraction of declared word count that
+// must be extracted for the content to be considered complete.
+const truncatedWordCountRatio = 0.5
+
+// checkTruncation compares the extracted word count against the word
+// count declared in JSON-LD. Returns the declared count, and a warning
+// if the extracted content is far shorter than declared, which commonly
+// happens on paywalled and partially rendered pages. Neither the length
+// threshold nor paywall markers catch those.
+func (ps *Parser) checkTruncation(jsonLd map[string]string, extractedWordCount int) (int, string) {
+	declared, err := strconv.Atoi(strings.ReplaceAll(jsonLd["wordCount"], ",", ""))
+	if err != nil || declared <= 0 {
+		return 0, ""
+	}
+
+	if float64(extractedWordCount) >= float64(declared)*truncatedWordCountRatio {
+		return declared, ""
+	}
+
+	return declared, fmt.Sprintf("content might be truncated: "+
+		"extracted %d words, but JSON-LD declares %d words", extractedWordCount, declared)
+}
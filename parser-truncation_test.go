@@ -0,0 +1,41 @@
+package readability
+
+import (
+	"testing"
+)
+
+func Test_checkTruncation(t *testing.T) {
+	scenarios := []struct {
+		wordCount string
+		declared  int
+		truncated bool
+	}{
+		{``, 0, false},
+		{`"wordCount": 150,`, 150, false},
+		{`"wordCount": "2,000",`, 2000, true},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head><script type="application/ld+json">{` +
+			`"@context": "https://schema.org", "@type": "NewsArticle", ` + scenario.wordCount +
+			`"headline": "Test"}</script></head><body><article>` +
+			testParagraph + testParagraph + `</article></body></html>`
+
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.DeclaredWordCount != scenario.declared || article.Truncated != scenario.truncated {
+			t.Errorf("\n"+
+				"word count : %s\n"+
+				"want       : %d words, truncated %v\n"+
+				"got        : %d words, truncated %v", scenario.wordCount,
+				scenario.declared, scenario.truncated, article.DeclaredWordCount, article.Truncated)
+		}
+
+		if article.WordCount != 192 {
+			t.Errorf("\nwant 192 extracted words, got %d", article.WordCount)
+		}
+
+		if scenario.truncated != (len(article.Warnings) == 1) {
+			t.Errorf("\nwant warning only for truncated content, got %q", article.Warnings)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_twitterCard(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		head    string
+		title   string
+		excerpt string
+		image   string
+	}{
+		{"twitter only",
+			`<meta name="twitter:card" content="summary_large_image">` +
+				`<meta name="twitter:title" content="Twitter Title">` +
+				`<meta name="twitter:description" content="Twitter description.">` +
+				`<meta name="twitter:image" content="/images/twitter.jpg">`,
+			"Twitter Title", "Twitter description.", "http://fakehost/images/twitter.jpg"},
+		{"twitter image src",
+			`<meta name="twitter:title" content="Twitter Title">` +
+				`<meta name="twitter:image:src" content="https://example.com/twitter.jpg">`,
+			"Twitter Title", "", "https://example.com/twitter.jpg"},
+		{"twitter below og",
+			`<meta property="og:title" content="OG Title">` +
+				`<meta name="twitter:title" content="Twitter Title">` +
+				`<meta name="twitter:description" content="Twitter description.">` +
+				`<meta name="description" content="Plain description.">`,
+			"OG Title", "Twitter description.", ""},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head><title>Page title</title>` + scenario.head + `</head>` +
+			`<body><article>` + testParagraph + testParagraph + `</article></body></html>`
+
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Title != scenario.title {
+			t.Errorf("\n%s: want title %q, got %q", scenario.name, scenario.title, article.Title)
+		}
+
+		excerpt := scenario.excerpt
+		if excerpt == "" {
+			excerpt = strings.TrimSpace(strings.Repeat(testSentence, 12))
+		}
+		if article.Excerpt != excerpt {
+			t.Errorf("\n%s: want excerpt %q, got %q", scenario.name, excerpt, article.Excerpt)
+		}
+
+		if article.Image != scenario.image {
+			t.Errorf("\n%s: want image %q, got %q", scenario.name, scenario.image, article.Image)
+		}
+	}
+}
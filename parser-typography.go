@@ -0,0 +1,55 @@
+package readability
+
+import (
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// Default replacements for dashes when normalizing typography.
+const (
+	defaultEmDashReplacement = "--"
+	defaultEnDashReplacement = "-"
+)
+
+// typographyReplacer returns replacer that converts curly quotes to
+// straight quotes, em and en dashes to the configured forms, and
+// ellipsis char to three dots.
+func (ps *Parser) typographyReplacer() *strings.Replacer {
+	emDash := strOr(ps.EmDashReplacement, defaultEmDashReplacement)
+	enDash := strOr(ps.EnDashReplacement, defaultEnDashReplacement)
+
+	return strings.NewReplacer(
+		"‘", "'", "’", "'", "‚", "'", "‛", "'",
+		"“", `"`, "”", `"`, "„", `"`, "‟", `"`,
+		"—", emDash,
+		"–", enDash,
+		"…", "...",
+	)
+}
+
+// normalizeTypography normalizes the typographic chars in every text
+// node of article content, except the ones inside code and preformatted
+// text whose chars must be kept as it is.
+func (ps *Parser) normalizeTypography(articleContent *html.Node, replacer *strings.Replacer) {
+	var normalizer func(*html.Node)
+	normalizer = func(node *html.Node) {
+		switch node.Type {
+		case html.TextNode:
+			node.Data = replacer.Replace(node.Data)
+			return
+		case html.ElementNode:
+			switch dom.TagName(node) {
+			case "pre", "code", "kbd", "samp":
+				return
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			normalizer(child)
+		}
+	}
+
+	normalizer(articleContent)
+}
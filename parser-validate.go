@@ -0,0 +1,95 @@
+package readability
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationCode identifies the kind of validation failure.
+type ValidationCode string
+
+// Validation failures returned by Article.Validate.
+const (
+	ValidationMissingTitle     ValidationCode = "missing_title"
+	ValidationMissingByline    ValidationCode = "missing_byline"
+	ValidationMissingDate      ValidationCode = "missing_date"
+	ValidationMissingImage     ValidationCode = "missing_image"
+	ValidationShortContent     ValidationCode = "short_content"
+	ValidationHighLinkDensity  ValidationCode = "high_link_density"
+	ValidationTruncatedContent ValidationCode = "truncated_content"
+)
+
+// ValidationRules is the requirements checked by Article.Validate. The
+// zero value of each rule disables it.
+type ValidationRules struct {
+	// RequireTitle requires the article to have a title.
+	RequireTitle bool
+	// RequireByline requires the article to have a byline.
+	RequireByline bool
+	// RequireDate requires the article to have the published time.
+	RequireDate bool
+	// RequireImage requires the article to have the lead image.
+	RequireImage bool
+	// MinContentLength is the minimum number of chars in TextContent.
+	MinContentLength int
+	// MaxLinkDensity is the maximum ratio of link text to the whole text
+	// in the content, between 0 and 1.
+	MaxLinkDensity float64
+	// RejectTruncated rejects the article whose content is detected as
+	// truncated, see Article.Truncated.
+	RejectTruncated bool
+}
+
+// ValidationError is a rule which not satisfied by the article.
+type ValidationError struct {
+	Code    ValidationCode
+	Message string
+}
+
+// Error returns the message of validation error.
+func (err ValidationError) Error() string {
+	return err.Message
+}
+
+// Validate checks the article against the rules, and returns every rule
+// that it fails, in the order they're declared in ValidationRules.
+// Returns nil if the article is valid.
+func (article Article) Validate(rules ValidationRules) []ValidationError {
+	var errs []ValidationError
+	fail := func(code ValidationCode, format string, args ...interface{}) {
+		errs = append(errs, ValidationError{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if rules.RequireTitle && strings.TrimSpace(article.Title) == "" {
+		fail(ValidationMissingTitle, "article has no title")
+	}
+
+	if rules.RequireByline && strings.TrimSpace(article.Byline) == "" {
+		fail(ValidationMissingByline, "article has no byline")
+	}
+
+	if rules.RequireDate && article.PublishedTime == nil {
+		fail(ValidationMissingDate, "article has no published time")
+	}
+
+	if rules.RequireImage && article.Image == "" {
+		fail(ValidationMissingImage, "article has no image")
+	}
+
+	if length := charCount(article.TextContent); rules.MinContentLength > 0 && length < rules.MinContentLength {
+		fail(ValidationShortContent, "content has %d chars, less than %d", length, rules.MinContentLength)
+	}
+
+	if rules.MaxLinkDensity > 0 && article.Node != nil {
+		var ps Parser
+		if density := ps.getLinkDensity(article.Node); density > rules.MaxLinkDensity {
+			fail(ValidationHighLinkDensity, "link density of content is %.2f, more than %.2f", density, rules.MaxLinkDensity)
+		}
+	}
+
+	if rules.RejectTruncated && article.Truncated {
+		fail(ValidationTruncatedContent, "content is truncated")
+	}
+
+	return errs
+}
@@ -0,0 +1,41 @@
+package readability
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_articleValidate(t *testing.T) {
+	links := "<p>" + strings.Repeat(`<a href="/other">Some other article to read</a> `, 20) + "</p>"
+	rawHTML := `<html><head><title>Test title</title></head><body><article>` +
+		testParagraph + testParagraph + links + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+
+	if errs := article.Validate(ValidationRules{RequireTitle: true, MinContentLength: 500}); errs != nil {
+		t.Errorf("\nwant valid article, got %v", errs)
+	}
+
+	errs := article.Validate(ValidationRules{
+		RequireTitle:     true,
+		RequireDate:      true,
+		MinContentLength: 100000,
+		MaxLinkDensity:   0.1,
+	})
+
+	var codes []ValidationCode
+	for _, err := range errs {
+		codes = append(codes, err.Code)
+		if err.Error() == "" {
+			t.Errorf("\nwant message for %s", err.Code)
+		}
+	}
+
+	expected := []ValidationCode{ValidationMissingDate, ValidationShortContent, ValidationHighLinkDensity}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("\n"+
+			"want : %v\n"+
+			"got  : %v", expected, codes)
+	}
+}
@@ -0,0 +1,117 @@
+package readability
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-shiori/dom"
+	"golang.org/x/net/html"
+)
+
+// getLeadVideoPoster checks whether the article content opens with a
+// video, i.e. there are no text or image before it. Returns the poster
+// of the video as well, which already made absolute while the content
+// post-processed. Embedded players (e.g. YouTube) are considered as
+// video too, but they don't have any poster.
+func (ps *Parser) getLeadVideoPoster(articleContent *html.Node) (bool, string) {
+	var isLeadVideo bool
+	var poster string
+	var finder func(*html.Node) bool
+
+	// finder returns true once either a video, an image or text is found.
+	finder = func(node *html.Node) bool {
+		switch node.Type {
+		case html.TextNode:
+			return strings.TrimSpace(node.Data) != ""
+		case html.ElementNode:
+			switch dom.TagName(node) {
+			case "video":
+				isLeadVideo = true
+				poster = strings.TrimSpace(dom.GetAttribute(node, "poster"))
+				return true
+			case "iframe", "embed", "object":
+				if ps.isAllowedEmbed(node) {
+					isLeadVideo = true
+					return true
+				}
+			case "img":
+				width, err := strconv.Atoi(dom.GetAttribute(node, "width"))
+				return err != nil || width >= 100
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if finder(child) {
+				return true
+			}
+		}
+		return false
+	}
+
+	finder(articleContent)
+	return isLeadVideo, poster
+}
+
+// getJSONLDVideoThumbnail returns URL of the thumbnail of VideoObject in
+// JSON-LD, which might be declared in its thumbnailUrl (string or list
+// of string) or thumbnail (ImageObject). If there are several videos,
+// the first one is used.
+func (ps *Parser) getJSONLDVideoThumbnail(video interface{}) string {
+	switch val := video.(type) {
+	case []interface{}:
+		if len(val) > 0 {
+			return ps.getJSONLDVideoThumbnail(val[0])
+		}
+	case map[string]interface{}:
+		switch thumbnailURL := val["thumbnailUrl"].(type) {
+		case string:
+			return strings.TrimSpace(thumbnailURL)
+		case []interface{}:
+			if len(thumbnailURL) > 0 {
+				if url, isString := thumbnailURL[0].(string); isString {
+					return strings.TrimSpace(url)
+				}
+			}
+		}
+
+		return ps.getJSONLDImageURL(val["thumbnail"])
+	}
+	return ""
+}
+
+// findJSONLDVideo finds the first VideoObject in JSON-LD value, which
+// might be an array of objects or a graph.
+func findJSONLDVideo(value interface{}) map[string]interface{} {
+	switch val := value.(type) {
+	case []interface{}:
+		for _, item := range val {
+			if objVideo := findJSONLDVideo(item); objVideo != nil {
+				return objVideo
+			}
+		}
+
+	case map[string]interface{}:
+		if isJSONLDVideo(val["@type"]) {
+			return val
+		}
+		return findJSONLDVideo(val["@graph"])
+	}
+
+	return nil
+}
+
+// isJSONLDVideo determines if the JSON-LD @type, which might be a single
+// type or list of types, is a VideoObject.
+func isJSONLDVideo(objType interface{}) bool {
+	switch val := objType.(type) {
+	case string:
+		return val == "VideoObject"
+	case []interface{}:
+		for _, item := range val {
+			if isJSONLDVideo(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
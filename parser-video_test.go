@@ -0,0 +1,51 @@
+package readability
+
+import (
+	"testing"
+)
+
+func Test_videoPoster(t *testing.T) {
+	jsonLd := `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+		`"video":{"@type":"VideoObject","thumbnailUrl":["/thumbs/video.jpg"]}}</script>`
+
+	// Video-led pages might only declare the video, without article.
+	videoJSONLd := `<script type="application/ld+json">{"@context":"https://schema.org","@type":"VideoObject",` +
+		`"name":"Lead video","thumbnailUrl":"/thumbs/standalone.jpg"}</script>`
+	graphJSONLd := `<script type="application/ld+json">{"@context":"https://schema.org","@graph":[` +
+		`{"@type":"WebPage","name":"Video page"},` +
+		`{"@type":"VideoObject","thumbnail":{"@type":"ImageObject","url":"/thumbs/graph.jpg"}}]}</script>`
+
+	scenarios := []struct {
+		head        string
+		content     string
+		videoPoster string
+		image       string
+	}{
+		{``, `<video poster="/posters/lead.jpg" src="/lead.mp4"></video>`,
+			"http://fakehost/posters/lead.jpg", "http://fakehost/posters/lead.jpg"},
+		{jsonLd, `<iframe src="https://www.youtube.com/embed/abc"></iframe>`,
+			"http://fakehost/thumbs/video.jpg", "http://fakehost/thumbs/video.jpg"},
+		{`<meta property="og:image" content="http://fakehost/og.jpg">`, `<video poster="/posters/lead.jpg" src="/lead.mp4"></video>`,
+			"http://fakehost/posters/lead.jpg", "http://fakehost/og.jpg"},
+		{jsonLd, `<p>Intro text before the video.</p><video poster="/posters/late.jpg" src="/late.mp4"></video>`,
+			"", ""},
+		{videoJSONLd, `<iframe src="https://www.youtube.com/embed/abc"></iframe>`,
+			"http://fakehost/thumbs/standalone.jpg", "http://fakehost/thumbs/standalone.jpg"},
+		{graphJSONLd, `<iframe src="https://www.youtube.com/embed/abc"></iframe>`,
+			"http://fakehost/thumbs/graph.jpg", "http://fakehost/thumbs/graph.jpg"},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head>` + scenario.head + `</head><body><article>` +
+			scenario.content + testParagraph + testParagraph + `</article></body></html>`
+
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.VideoPoster != scenario.videoPoster || article.Image != scenario.image {
+			t.Errorf("\n"+
+				"content : %s\n"+
+				"want    : poster %q, image %q\n"+
+				"got     : poster %q, image %q", scenario.content,
+				scenario.videoPoster, scenario.image, article.VideoPoster, article.Image)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package readability
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	shtml "html"
@@ -16,6 +17,13 @@ import (
 	"golang.org/x/net/html"
 )
 
+// Default scoring options, the same as Readability.js.
+const (
+	defaultCharThresholds        = 500
+	defaultNTopCandidates        = 5
+	defaultSiblingScoreThreshold = 0.2
+)
+
 // All of the regular expressions in use within readability.
 // Defined up here so we don't instantiate them repeatedly in loops *.
 var (
@@ -33,8 +41,8 @@ var (
 	rxWhitespace           = regexp.MustCompile(`(?i)^\s*$`)
 	rxHasContent           = regexp.MustCompile(`(?i)\S$`)
 	rxHashURL              = regexp.MustCompile(`(?i)^#.+`)
-	rxPropertyPattern      = regexp.MustCompile(`(?i)\s*(dc|dcterm|og|twitter)\s*:\s*(author|creator|description|title|site_name|image\S*)\s*`)
-	rxNamePattern          = regexp.MustCompile(`(?i)^\s*(?:(dc|dcterm|og|twitter|weibo:(article|webpage))\s*[\.:]\s*)?(author|creator|description|title|site_name|image)\s*$`)
+	rxPropertyPattern      = regexp.MustCompile(`(?i)\s*(dc|dcterm|og|twitter)\s*:\s*(author|creator|description|title|site_name|logo|image\S*|type|url)\s*`)
+	rxNamePattern          = regexp.MustCompile(`(?i)^\s*(?:(dc|dcterm|og|twitter|weibo:(article|webpage))\s*[\.:]\s*)?(author|creator|description|title|site_name|image|image:src)\s*$`)
 	rxTitleSeparator       = regexp.MustCompile(`(?i) [\|\-\\/>»] `)
 	rxTitleHierarchySep    = regexp.MustCompile(`(?i) [\\/>»] `)
 	rxTitleRemoveFinalPart = regexp.MustCompile(`(?i)(.*)[\|\-\\/>»] .*`)
@@ -53,6 +61,35 @@ var (
 	rxCDATA                = regexp.MustCompile(`^\s*<!\[CDATA\[|\]\]>\s*$`)
 	rxSchemaOrg            = regexp.MustCompile(`(?i)^https?\:\/\/schema\.org$`)
 	rxCharset              = regexp.MustCompile(`(?i)charset\s*=\s*([^;\s"]+)`)
+	rxLiveBlog             = regexp.MustCompile(`(?i)live-?blog|live-?coverage|live-?updates`)
+	rxMojibake             = regexp.MustCompile(`[\x{C2}-\x{DF}][\x{80}-\x{BF}€‚ƒ„…†‡ˆ‰Š‹ŒŽ‘’“”•–—˜™š›œžŸ]|[\x{E0}-\x{EF}][\x{80}-\x{BF}€‚ƒ„…†‡ˆ‰Š‹ŒŽ‘’“”•–—˜™š›œžŸ]{2}`)
+	rxDateline             = regexp.MustCompile(`^\s*([A-Z][A-Z.'\-]{2,}(?:\s+[A-Z][A-Z.'\-]+)*)(?:,\s*([A-Z][a-z]{2,8}\.?\s+\d{1,2}(?:,\s*\d{4})?))?\s*(?:\(([^()]{1,40})\))?(?:\s*—\s*|\s+(?:–|--|-)\s+)`)
+	rxLiveDateline         = regexp.MustCompile(`^\W*(BREAKING|LIVE)\b`)
+	rxPullQuote            = regexp.MustCompile(`(?i)pull-?quote`)
+	rxCommentCount         = regexp.MustCompile(`(?i)^(?:(\d{1,3}(?:[.,\x{a0} ]\d{3})+|\d+)\s+(?:comments?|responses?|replies)|(?:comments?|responses?|replies)\s*[:(]?\s*(\d{1,3}(?:[.,\x{a0} ]\d{3})+|\d+)\)?)$`)
+	rxCommentsSection      = regexp.MustCompile(`(?i)\bcomments?\b|disqus|respond`)
+	rxShareURL             = regexp.MustCompile(`(?i)(facebook\.com/(sharer|share\.php|dialog/share)|(twitter|x)\.com/(intent/(tweet|post)|share)|linkedin\.com/(sharearticle|sharing|shareArticle)|pinterest\.[a-z.]+/pin/create|reddit\.com/submit|tumblr\.com/(share|widgets/share)|api\.whatsapp\.com/send|whatsapp://send|wa\.me/\?|t\.me/share|telegram\.me/share|news\.ycombinator\.com/submitlink|getpocket\.com/(save|edit)|^mailto:\?)`)
+	rxShareButton          = regexp.MustCompile(`(?i)(\b|_)(share|sharing|social-?share|tweet)(\b|_)`)
+	rxRGBColor             = regexp.MustCompile(`^rgba?\(\s*(\d+)\s*[,\s]\s*(\d+)\s*[,\s]\s*(\d+)`)
+	rxReferences           = regexp.MustCompile(`(?i)\b(references|reflist|citations|bibliography|sources|endnotes)\b`)
+	rxReferencesHeading    = regexp.MustCompile(`(?i)^(references|sources|citations|bibliography|works cited|further reading|notes and references)$`)
+	rxReadingTimeLabel     = regexp.MustCompile(`(?i)read(ing)?\s*time|time\s*to\s*read|min(ute)?s?\s*read`)
+	rxISO8601Duration      = regexp.MustCompile(`(?i)^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+	rxReadingTimeHours     = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:h|hr|hrs|hour|hours)\b`)
+	rxReadingTimeMinutes   = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:m|min|mins|minute|minutes)?\b`)
+	rxCodeLanguageClass    = regexp.MustCompile(`(?i)^(?:language|lang|highlight-source|highlight)-([a-z0-9_+#.-]+)$`)
+	rxImagePathSize        = regexp.MustCompile(`[/_-](\d{2,5})x(\d{2,5})(?:[/._-]|$)`)
+	rxImageCloudinarySize  = regexp.MustCompile(`(?:^|[/,])w_(\d{2,5}),h_(\d{2,5})(?:[/,]|$)`)
+	rxCorrections          = regexp.MustCompile(`(?i)\bcorrections?\b|editors?-?note|clarification`)
+	rxCorrectionText       = regexp.MustCompile(`(?i)^(corrections?|clarifications?|editor['’]?s note)\s*[:.—–-]|^an earlier version of this (article|story|post)\b|^this (article|story|post) (has been|was) (corrected|updated|amended)\b`)
+	rxSponsored            = regexp.MustCompile(`(?i)(^|[\s_-])(sponsored|advertorial|partner-?content|paid-?(post|content))($|[\s_-])`)
+	rxSponsoredLabel       = regexp.MustCompile(`(?i)^(sponsored( content| post| story)?|sponsored by\b.*|paid (content|post|partnership)|advertorial|partner content|in partnership with\b.*)$`)
+	rxImageCreditClass     = regexp.MustCompile(`(?i)credit|copyright|attribution|photographer|byline|source`)
+	rxImageCreditText      = regexp.MustCompile(`(?i)(?:^|[\s.(\[|])(?:(?:photo(?:graph)?|image|picture|illustration|credit)s?(?:\s+by\s|\s*[:/])|©)\s*([^()\[\]|]+?)[)\]]?\s*$`)
+	rxJsonLdEventType      = regexp.MustCompile(`(?i)^\w*Event$`)
+	rxEpochTimestamp       = regexp.MustCompile(`^[1-9](\d{9}|\d{12})$`)
+	rxSpacerImage          = regexp.MustCompile(`(?i)(^|[/_.-])(spacer|blank|pixel|transparent|1x1|placeholder)[^/]*\.(gif|png)(\?.*)?$`)
+	rxGenericLinkText      = regexp.MustCompile(`(?i)^(here|click here|this|link|more|read more|continue reading|source|via|see also|this article|this post|\[?\d+\]?)$`)
 )
 
 // Constants that used by readability.
@@ -60,6 +97,7 @@ var (
 	unlikelyRoles                = sliceToMap("menu", "menubar", "complementary", "navigation", "alert", "alertdialog", "dialog")
 	divToPElems                  = sliceToMap("blockquote", "dl", "div", "img", "ol", "p", "pre", "table", "ul", "select")
 	alterToDivExceptions         = []string{"div", "article", "section", "p"}
+	classesToPreserve            = []string{"page"}
 	presentationalAttributes     = []string{"align", "background", "bgcolor", "border", "cellpadding", "cellspacing", "frame", "hspace", "rules", "style", "valign", "vspace"}
 	deprecatedSizeAttributeElems = []string{"table", "th", "td", "hr", "pre"}
 	phrasingElems                = []string{
@@ -68,6 +106,25 @@ var (
 		"mark", "math", "meter", "noscript", "object", "output", "progress", "q",
 		"ruby", "samp", "script", "select", "small", "span", "strong", "sub",
 		"sup", "textarea", "time", "var", "wbr"}
+	blockElems = sliceToMap(
+		"address", "article", "aside", "blockquote", "body", "details", "dd",
+		"div", "dl", "dt", "fieldset", "figcaption", "figure", "footer", "form",
+		"h1", "h2", "h3", "h4", "h5", "h6", "header", "hr", "li", "main", "nav",
+		"ol", "p", "pre", "section", "summary", "table", "tbody", "td", "tfoot",
+		"th", "thead", "tr", "ul")
+	defaultCandidateTags = []string{"div"}
+	defaultTagWeights    = map[string]int{
+		"pre": 3, "td": 3, "blockquote": 3,
+		"address": -3, "ol": -3, "ul": -3, "dl": -3, "dt": -3, "li": -3, "form": -3,
+		"h1": -5, "h2": -5, "h3": -5, "h4": -5, "h5": -5, "h6": -5, "th": -5,
+	}
+	preservedRootTags  = []string{"article", "main", "section"}
+	basicColorKeywords = map[string]string{
+		"black": "#000000", "silver": "#c0c0c0", "gray": "#808080", "white": "#ffffff",
+		"maroon": "#800000", "red": "#ff0000", "purple": "#800080", "fuchsia": "#ff00ff",
+		"green": "#008000", "lime": "#00ff00", "olive": "#808000", "yellow": "#ffff00",
+		"navy": "#000080", "blue": "#0000ff", "teal": "#008080", "aqua": "#00ffff",
+	}
 )
 
 // flags is flags that used by parser.
@@ -77,71 +134,580 @@ type flags struct {
 	cleanConditionally bool
 }
 
+// parseState is the data of the document being parsed. It's kept apart
+// from the configuration of Parser, since every parse works on its own
+// copy of Parser (see withParseState), so one Parser can be used by
+// several goroutines at once.
+type parseState struct {
+	ctx                context.Context
+	doc                *html.Node
+	documentURI        *nurl.URL
+	articleTitle       string
+	articleByline      string
+	articleDir         string
+	articleSiteName    string
+	articleBylineImage string
+	attempts           []parseAttempt
+	flags              flags
+
+	rootSelectionMethod string
+	// topCandidateClass is the class and id of the top candidate of
+	// article content, kept since they're removed while cleaning up.
+	topCandidateClass string
+}
+
+// withParseState returns a copy of the parser with fresh state for
+// parsing doc, which fetched from pageURL. The configuration is only
+// read while parsing, so it's safe to share with the original.
+func (ps *Parser) withParseState(ctx context.Context, doc *html.Node, pageURL *nurl.URL) *Parser {
+	parser := *ps
+	parser.parseState = parseState{
+		ctx:         ctx,
+		doc:         doc,
+		documentURI: pageURL,
+		attempts:    []parseAttempt{},
+		flags: flags{
+			stripUnlikelys:     true,
+			useWeightClasses:   true,
+			cleanConditionally: true,
+		},
+	}
+	return &parser
+}
+
+// canceled determines if the context of current parse is done. Parser
+// which state isn't set up (e.g. in tests) is never canceled.
+func (ps *Parser) canceled() bool {
+	return ps.ctx != nil && ps.ctx.Err() != nil
+}
+
 // parseAttempt is container for the result of previous parse attempts.
 type parseAttempt struct {
-	articleContent *html.Node
-	textLength     int
+	articleContent    *html.Node
+	textLength        int
+	flags             flags
+	topCandidateClass string
 }
 
 // Article is the final readable content.
 type Article struct {
-	Title         string
-	Byline        string
-	Node          *html.Node
-	Content       string
-	TextContent   string
-	Length        int
-	Excerpt       string
-	SiteName      string
+	Title string
+	// Byline is the author of article, without the prefix like "By" and
+	// the trailing fragments like date or section.
+	Byline string
+	// RawByline is the byline as found in the metadata or content, before
+	// it's cleaned.
+	RawByline   string
+	Node        *html.Node
+	Content     string
+	TextContent string
+	// Length is the number of chars in TextContent.
+	Length int
+	// WordCount is the number of words in TextContent, i.e. the tokens
+	// separated by whitespace.
+	WordCount int
+	Excerpt   string
+	SiteName  string
+	// Tags is the topics of article declared by the publisher, merged
+	// from JSON-LD keywords, article:tag meta and keywords meta.
+	Tags []string
+	// OpenGraphType is the type of page declared in og:type meta, e.g.
+	// "article" or "website".
+	OpenGraphType string
+	// OpenGraphURL is the URL of page declared in og:url meta.
+	OpenGraphURL string
+	// Language is the primary language subtag of the document, in
+	// lowercase (e.g. "en" for "en-US"). Empty if the document doesn't
+	// declare its language.
+	Language string
+	// LanguageTag is the language as declared in the document, e.g.
+	// "en-US" from <html lang> or "en_US" from og:locale.
+	LanguageTag   string
 	Image         string
 	Favicon       string
 	PublishedTime *time.Time
 	ModifiedTime  *time.Time
+	// Authors is the names of authors declared in JSON-LD. If there are
+	// none, it only contains the byline found in metadata or content.
+	Authors []string
+	// AuthorImage is the URL of the author's avatar or photo.
+	AuthorImage string
+	// PublisherLogo is the URL of the publisher's logo.
+	PublisherLogo string
+	// Dateline is the place, news agency and date which written at
+	// the start of the article. Nil if the article has no dateline.
+	Dateline *Dateline
+	// ImageCount is the number of images kept in the content.
+	ImageCount int
+	// VideoCount is the number of videos kept in the content, which
+	// includes <video> and embedded video players (e.g. YouTube).
+	VideoCount int
+	// Quotes is the list of block quotes in the content, along with
+	// their attribution.
+	Quotes []QuoteInfo
+	// Images is the list of images in the content, along with their
+	// caption and credit.
+	Images []ImageInfo
+	// RootSelectionMethod is how the root of content is chosen, either
+	// "semantic", "heuristic", "body" or "longest-attempt". See the
+	// RootSelection constants for their meaning. Empty if no content is
+	// found.
+	RootSelectionMethod string
+	// Event is the details of event declared in JSON-LD, for event
+	// listing articles. Nil if there are none.
+	Event *EventInfo
+	// Annotations is the list of elements in content which annotated
+	// with attributes listed in Parser.ExtractDataAnnotations.
+	Annotations []Annotation
+	// Sections is the flat list of top-level sections in content, split
+	// at <h2>, with their ranges in TextContent for tracking progress.
+	Sections []Section
+	// DeclaredReadingTime is the reading time which declared by the
+	// publisher in metadata. Nil if there are none.
+	DeclaredReadingTime *time.Duration
+	// DeclaredReadingTimeSource is the metadata convention where the
+	// declared reading time is taken from, either "json-ld" (timeRequired),
+	// "twia:reading_time" or "twitter:data" (labelled twitter:dataN).
+	DeclaredReadingTimeSource string
+	// ReadingTime is the estimated time to read TextContent, based on
+	// Parser.WordsPerMinute. Text in Chinese and Japanese, which isn't
+	// separated by space, is counted by chars instead of words.
+	ReadingTime time.Duration
+	// StartsWithImage is true if the content opens with an image
+	// before any text, e.g. a hero image.
+	StartsWithImage bool
+	// HeaderImage is the URL of the image that opens the content, if
+	// StartsWithImage is true. It's often the same as Image.
+	HeaderImage string
+	// VideoPoster is the URL of the preview image of the video that
+	// opens the content, taken from its poster attribute or from the
+	// VideoObject thumbnail in JSON-LD. If the page doesn't declare its
+	// image, it's used as Image as well.
+	VideoPoster string
+	// References is the list of items in references or sources section
+	// of the article. Only extracted if Parser.ExtractReferences is true.
+	References []Reference
+	// ThemeColor is the theme color declared by the site in #rrggbb
+	// format. If the site declares different colors for light and dark
+	// color scheme, this is the light one.
+	ThemeColor string
+	// ThemeColorDark is the theme color declared by the site for dark
+	// color scheme in #rrggbb format. Empty if there are none.
+	ThemeColorDark string
+	// CommentCount is the number of comments on the article. Zero if
+	// it's not found.
+	CommentCount int
+	// CommentCountSource is where the comment count is taken from,
+	// either "json-ld" (commentCount) or "text" (visible text, which is
+	// less reliable). Empty if comment count is not found.
+	CommentCountSource string
+	// IsAMP is true if the page itself is an AMP page.
+	IsAMP bool
+	// AMPURL is the URL of AMP version of the page, which declared in
+	// <link rel="amphtml">. Empty if there are none.
+	AMPURL string
+	// CanonicalURL is the canonical URL of the page, which declared in
+	// <link rel="canonical"> or og:url meta. Empty if there are none,
+	// instead of falling back to the page URL.
+	CanonicalURL string
+	// Timings is the duration of each major phase of parsing, keyed by
+	// "parse", "getJSONLD", "prepDocument", "grabArticle",
+	// "postProcessContent" and "parseDocument" (the whole ParseDocument).
+	// Only collected if Parser.CollectTimings is true.
+	Timings map[string]time.Duration
+	// SummarySentences is the most representative sentences in the
+	// content, in their original order. Only extracted if
+	// Parser.ExtractSummary is set.
+	SummarySentences []string
+	// Topics is the anchor texts of links inside the content, most
+	// frequently linked first. Only extracted if Parser.ExtractTopics
+	// is true.
+	Topics []string
+	// Sponsored is true if the article is sponsored content, as declared
+	// in JSON-LD (AdvertiserContentArticle or sponsor property), in meta
+	// article:sponsor, in class of the article's container, or by label
+	// like "Sponsored" or "Paid Post" between the title and the body.
+	Sponsored bool
+	// Corrections is the text of corrections and editor's notes of the
+	// article, in the order they appear in the page. Only extracted if
+	// Parser.ExtractCorrections is true.
+	Corrections []string
+	// LiveEntries is the timestamped entries of live blog, in the order
+	// they appear in the page. Only extracted if Parser.DetectLiveBlog is
+	// true, and empty for normal articles.
+	LiveEntries []LiveEntry
+	// Freshness is a coarse time-sensitivity bucket of the article,
+	// either "live", "recent", "dated" or "evergreen".
+	Freshness string
+	// DeclaredWordCount is the word count declared in JSON-LD, which is
+	// compared against WordCount. Zero if it's not declared.
+	DeclaredWordCount int
+	// Truncated is true if the extracted content is far shorter than
+	// the declared word count, which is a strong signal of paywall or
+	// partially rendered page. The cut by Parser.AboveFoldOnly is not
+	// counted, see CutAtFold.
+	Truncated bool
+	// CutAtFold is true if the content is cut by Parser.AboveFoldOnly.
+	CutAtFold bool
+	// Warnings is the list of problems found in the result that don't
+	// prevent the article from being returned, e.g. truncated content.
+	Warnings []string
+}
+
+// QuoteInfo is a block quote in the article, along with the source
+// it's quoted from.
+type QuoteInfo struct {
+	Text      string
+	Source    string
+	SourceURL string
 }
 
 // Parser is the parser that parses the page to get the readable content.
+// Once configured, it's safe to be used by multiple goroutines at once,
+// as long as its fields are not modified meanwhile.
 type Parser struct {
 	// MaxElemsToParse is the max number of nodes supported by this
 	// parser. Default: 0 (no limit)
 	MaxElemsToParse int
+	// MinVisibleTextLength is the minimum number of visible chars in a
+	// page. If the page has fewer chars than this, it's considered empty
+	// and ErrNoContent is returned without trying to extract the content.
+	// Around 25 chars is enough to skip error pages and empty shells
+	// cheaply. Default: 0 (disabled).
+	MinVisibleTextLength int
+	// MaxCandidates is the max number of candidates whose score are
+	// scaled by link density, and the max number of top candidate's
+	// siblings considered for merging. It keeps the worst-case latency
+	// bounded on huge or flat pages (e.g. thousands of comments under
+	// one parent). Default: 0 (no limit).
+	MaxCandidates int
 	// NTopCandidates is the number of top candidates to consider when
-	// analysing how tight the competition is among candidates.
+	// analysing how tight the competition is among candidates. Zero or
+	// negative value falls back to the default. Default: 5.
 	NTopCandidates int
-	// CharThresholds is the default number of chars an article must
-	// have in order to return a result
+	// CharThresholds is the number of chars an article must have in order
+	// to return a result. When the content found by grabArticle is shorter,
+	// it's retried with less strict flags (keeping the unlikely candidates,
+	// ignoring the class weights and disabling conditional cleaning), and
+	// the longest attempt is used. Lower it for short-form content (e.g.
+	// news briefs or product pages) so the strict first attempt is
+	// accepted, at the cost of accepting a wrong short node on pages whose
+	// real content isn't found at first. Default: 500.
 	CharThresholds int
-	// ClassesToPreserve are the classes that readability sets itself.
+	// SiblingScoreThreshold is the fraction of top candidate's score that
+	// must be reached by its siblings to be merged into the article. Lower
+	// it to include more trailing content, or raise it to avoid merging
+	// unrelated blocks like related articles. The siblings must score at
+	// least 10 whatever the fraction is, so lowering it has no effect when
+	// the top candidate is weak (scored below 50 with the default). Zero
+	// or negative value falls back to the default. Default: 0.2.
+	SiblingScoreThreshold float64
+	// ConditionalCleanThresholds is the cutoffs used to decide whether
+	// the boilerplate-looking elements are removed from content. Loosen
+	// them for content-sparse sites whose legitimate paragraphs are
+	// removed. Default: the thresholds of Readability.js.
+	ConditionalCleanThresholds ConditionalCleanThresholds
+	// ClassesToPreserve are the classes which kept in content when the
+	// other classes are removed, e.g. "math", "caption" or the classes of
+	// syntax highlighter. The classes that readability sets itself (i.e.
+	// "page") are always kept. Default: ["page"].
 	ClassesToPreserve []string
 	// KeepClasses specify whether the classes should be stripped or not.
 	KeepClasses bool
-	// TagsToScore is element tags to score by default.
+	// KeepAttributes is the attributes which survive the cleaning of
+	// content, i.e. the presentational attributes (e.g. style, align or
+	// bgcolor), the size of tables, class and the unreferenced id removed
+	// by CleanIDs. The names are matched case-insensitively. Default:
+	// empty, so only those cleaned attributes are removed.
+	KeepAttributes []string
+	// CleanIDs determines if id attributes will be removed from content,
+	// except the ones referenced by links inside the content (e.g. the
+	// id="step-3" targeted by href="#step-3"), so in-page navigation keeps
+	// working without the noisy ids. Default: false.
+	CleanIDs bool
+	// PreserveRootTag determines if the original tag of the readable node
+	// (article, main or section) will be kept as the root of the content,
+	// instead of being normalized into div. This only applies when the
+	// content is taken from that single node. Default: false.
+	PreserveRootTag bool
+	// MergeMultipleArticles determines if sibling <article> elements
+	// which each have substantial content will be merged, in their order,
+	// into a single article before the content is grabbed. Useful for
+	// archive-style pages (e.g. newsletter archives) whose content is the
+	// union of the entries. When false, only the best entry is picked.
+	// Default: false.
+	MergeMultipleArticles bool
+	// RemoveShareButtons determines if clusters of social share buttons
+	// (e.g. "Share on Twitter" links) will be removed from content. The
+	// buttons are detected by their URL (share endpoint of social sites)
+	// and their labels. Links to social sites in the prose are kept.
+	// Default: true.
+	RemoveShareButtons bool
+	// DropAriaHidden determines if decorative elements marked with
+	// aria-hidden="true" (e.g. icon ligatures like "arrow_forward" and
+	// separators) will be removed while preparing the document. Hidden
+	// wrappers which contain blocks or long text are kept. Default: true.
+	DropAriaHidden bool
+	// PrettyPrintOutput determines if the content will be serialized with
+	// one block element per line and indentation, which is easier to read
+	// and diff (e.g. for golden-file tests). Whitespace inside <pre> is
+	// kept as it is. Default: false (compact).
+	PrettyPrintOutput bool
+	// NormalizeEmphasisTags determines if the emphasis tags in content
+	// will be normalized into semantic <strong> and <em>, or into
+	// presentational <b> and <i>, so the markup is consistent between
+	// sites. Default: EmphasisNone (kept as they are).
+	NormalizeEmphasisTags EmphasisNormalization
+	// InferImageDimensions determines if the width and height of images
+	// in content which don't declare them will be inferred from their
+	// srcset and URL, to prevent layout shift. The recognized patterns
+	// are listed in the doc of inferImageDimensions. Default: false.
+	InferImageDimensions bool
+	// ResolveSrcset determines if the src of images in content will be
+	// replaced by the largest candidate in their srcset or data-srcset,
+	// chosen by the width descriptor or else the pixel density, so the
+	// content doesn't use the low resolution fallback. Default: false.
+	ResolveSrcset bool
+	// KeepSrcset determines if the srcset of images is kept after it's
+	// resolved into src. Only used when ResolveSrcset is enabled.
+	// Default: false.
+	KeepSrcset bool
+	// NormalizeHeadingLevels determines if the headings in content will
+	// be renumbered so their levels are contiguous (e.g. h2 followed by h4
+	// becomes h2 followed by h3), while keeping their relative nesting.
+	// Useful for building outline or table of contents. Default: false.
+	NormalizeHeadingLevels bool
+	// UnwrapLayoutTables determines if the tables used for layout will be
+	// replaced by the content of their cells, so only the data tables,
+	// i.e. the ones with headers, caption or enough rows and columns,
+	// keep the table structure. Readability.js keeps the layout tables,
+	// so it's disabled by default. Default: false.
+	UnwrapLayoutTables bool
+	// AboveFoldOnly determines if the content will be cut at the
+	// heuristic fold, i.e. only the content likely visible without
+	// scrolling is kept, which is useful for previews and snippets. The
+	// fold is set at the first image or embed after some text, or after
+	// the first few blocks, whichever comes first. See cutAboveFold for
+	// the exact limits. Article.CutAtFold is set if the content is cut.
+	// Default: false.
+	AboveFoldOnly bool
+	// AnnotateOutput determines if semantic classes will be added to the
+	// recognized structures in content, to be used as stable hooks for
+	// styling. The classes are kept even when KeepClasses is false. The
+	// vocabulary is:
+	//
+	//   - rdbl-lead: the first paragraph which has text.
+	//   - rdbl-figure: <figure>.
+	//   - rdbl-caption: <figcaption>.
+	//   - rdbl-quote: <blockquote> which isn't a pull quote.
+	//   - rdbl-pullquote: <blockquote> or <aside> whose original class
+	//     or id mentions "pullquote" or "pull-quote".
+	//   - rdbl-code: <pre>.
+	//   - rdbl-table: <table> which contains data, not used for layout.
+	//   - rdbl-embed: <iframe>, <video>, <audio>, <embed> and <object>.
+	//
+	// Default: false.
+	AnnotateOutput bool
+	// TagsToScore is element tags to score by default. The definitions
+	// (<dd>) are scored like paragraphs, so glossaries count as content.
 	TagsToScore []string
+	// AllowedEmbedHosts is the hosts whose <iframe> is kept in content,
+	// e.g. video players. The host must match exactly, unless it's
+	// listed with "*." prefix, e.g. "*.example.com" allows every
+	// subdomain of example.com. The other iframes are removed.
+	// Default: YouTube, Vimeo and the other known video sites.
+	AllowedEmbedHosts []string
+	// StripTrackingParams determines if the tracking parameters listed in
+	// TrackingParams will be removed from the query of links in content.
+	// Default: false.
+	StripTrackingParams bool
+	// TrackingParams is the query parameters removed from links when
+	// StripTrackingParams is enabled. Name which ends with "*" matches
+	// every parameter with that prefix, e.g. "utm_*". Append to it to
+	// strip more parameters. Default: utm_*, fbclid, gclid and the other
+	// common click identifiers.
+	TrackingParams []string
+	// LazyImageAttributes is the attributes of <img> which might contain
+	// the real source of lazy-loaded image. When the src of image is
+	// empty, a data URI or a 1x1 spacer, the first of these attributes
+	// which has value is promoted into src.
+	// Default: ["data-src", "data-original", "data-lazy-src"].
+	LazyImageAttributes []string
+	// CandidateTags is the tags of elements which given head start while
+	// scored as candidate of the article container, the same way <div>
+	// is treated by Readability.js. Add "section" or "article" for sites
+	// which wrap their content in those instead of <div>. Default: ["div"].
+	CandidateTags []string
+	// TagWeights overrides the initial score given to elements by their
+	// tag while scored as candidate of the article container, e.g. set
+	// "section" to 10 for sites which use it as the main container. By
+	// default <div> (or the CandidateTags) is given 5, <pre>, <td> and
+	// <blockquote> 3, lists and forms -3 (except the definitions <dd>,
+	// which weighted like paragraphs), and headings -5. The tags that
+	// are not in the map keep their default weight. Default: nil.
+	TagWeights map[string]int
 	// Debug determines if the log should be printed or not. Default: false.
 	Debug bool
+	// Logger receives the problems found while parsing which don't stop
+	// the parsing, e.g. the date that can't be parsed. Default: nil, which
+	// discards the messages.
+	Logger Logger
+	// DetectCharset determines if input of Parse and ParseMetadataFast
+	// will be converted to UTF-8 before parsing, using the charset from
+	// its byte order mark or <meta> declaration. The declaration is
+	// ignored if input is already valid UTF-8. Without it, only the
+	// statistical guess while building the DOM is used. Default: true.
+	DetectCharset bool
 	// DisableJSONLD determines if metadata in JSON+LD will be extracted
 	// or not. Default: false.
 	DisableJSONLD bool
-
-	doc             *html.Node
-	documentURI     *nurl.URL
-	articleTitle    string
-	articleByline   string
-	articleDir      string
-	articleSiteName string
-	attempts        []parseAttempt
-	flags           flags
+	// UseNextData determines if article embedded in the data blob of
+	// Next.js (script#__NEXT_DATA__) will be extracted or not. Useful for sites whose server-rendered page is only an
+	// empty shell. Default: false.
+	UseNextData bool
+	// UseTemplateContent determines if substantial content stashed in
+	// inert <template> (used by some frameworks for hydration) will be
+	// moved into the document before the content is grabbed. If there
+	// are several, only the largest one is used. Default: false.
+	UseTemplateContent bool
+	// DetectLiveBlog determines if the timestamped entries of live blog
+	// will be extracted into Article.LiveEntries, in addition to the
+	// merged content. Default: false.
+	DetectLiveBlog bool
+	// StripDateline determines if the dateline at the start of article
+	// (e.g. "LONDON (Reuters) -") will be removed from the content.
+	// Default: false.
+	StripDateline bool
+	// RepairMojibake determines if text that wrongly decoded as Latin-1
+	// upstream (e.g. "Ã©" for "é") will be repaired in title, byline,
+	// excerpt and text content. Default: false.
+	RepairMojibake bool
+	// NormalizeTypography determines if typographic chars will be
+	// normalized in title, excerpt and content, which useful for search
+	// and diffing. Curly quotes are converted to straight quotes, ellipsis
+	// char to "...", and dashes to EmDashReplacement and EnDashReplacement.
+	// Text inside code and preformatted text is never changed.
+	// Default: false.
+	NormalizeTypography bool
+	// EmDashReplacement is the replacement of em dash (—) when typography
+	// is normalized. Default: "--".
+	EmDashReplacement string
+	// EnDashReplacement is the replacement of en dash (–) when typography
+	// is normalized. Default: "-".
+	EnDashReplacement string
+	// AllowedTags is the list of tags that allowed in the final content.
+	// If specified, elements with other tags will be unwrapped, i.e. the
+	// element is removed but its children are kept. Default: empty,
+	// which means all tags are allowed.
+	AllowedTags []string
+	// BlockedTags is the list of tags that always removed, along with
+	// their children, from the final content. Default: empty.
+	BlockedTags []string
+	// ExtractDataAnnotations is the list of attribute names (e.g.
+	// data-wikidata) whose elements will be collected as annotations
+	// in Article.Annotations. Default: empty.
+	ExtractDataAnnotations []string
+	// ExtractCommentCount determines if the number of comments will be
+	// parsed from the heading of comments section (e.g. "142 Comments")
+	// when it's not declared in JSON-LD. Default: false.
+	ExtractCommentCount bool
+	// ExtractReferences determines if the references or sources section
+	// (e.g. "References" list at the end of academic article) will be
+	// extracted into Article.References and kept out of the content.
+	// Default: false.
+	ExtractReferences bool
+	// ExtractCorrections determines if the corrections and editor's
+	// notes (e.g. "Correction: An earlier version of this article...")
+	// will be extracted into Article.Corrections. Default: false.
+	ExtractCorrections bool
+	// StripCorrections determines if the extracted corrections will be
+	// removed from content. Only used if ExtractCorrections is true.
+	// Default: false.
+	StripCorrections bool
+	// ExtractSummary is the number of representative sentences that will
+	// be extracted from content into Article.SummarySentences, using
+	// TextRank. It's only run for English documents (or the ones without
+	// declared language), since the stop words and sentence splitting are
+	// tuned for English. It costs quadratic time to the number of
+	// sentences, so only the first 300 sentences of content are ranked.
+	// Default: 0 (disabled).
+	ExtractSummary int
+	// MaxExcerptLength is the maximum number of chars in the excerpt.
+	// Longer excerpt is cut at word boundary, and ended with ellipsis
+	// which counted in the limit.
+	// Default: 0 (unbounded).
+	MaxExcerptLength int
+	// ExtractTopics determines if the anchor texts of links inside the
+	// content will be collected into Article.Topics, ranked by how often
+	// they're linked. On well-linked articles (e.g. Wikipedia) those are
+	// the key entities of the article. Default: false.
+	ExtractTopics bool
+	// ExtractCodeLanguages determines if the language declared in the
+	// classes of <pre> and <code> (e.g. "language-go", "lang-python",
+	// "highlight-source-js" or data-lang attribute) will be normalized
+	// into class="language-X" and kept in content, even when the other
+	// classes are removed. Common aliases are normalized as well, e.g.
+	// "js" into "javascript" and "golang" into "go". Regardless of this
+	// option, the class of <code> inside <pre> is always kept as it is,
+	// for the syntax highlighter downstream. Default: false.
+	ExtractCodeLanguages bool
+	// CollectTimings determines if the duration of each parsing phase
+	// will be measured into Article.Timings, which useful to monitor the
+	// performance. Default: false.
+	CollectTimings bool
+	// WordsPerMinute is the reading speed used to estimate
+	// Article.ReadingTime. Default: 200.
+	WordsPerMinute int
+	// CheckMinContentLength is the minimum number of chars in a paragraph
+	// for it to count toward the score of Check. Default: 140.
+	CheckMinContentLength int
+	// CheckMinScore is the score that paragraphs must exceed for Check
+	// to consider the page readable. Each counted paragraph adds the
+	// square root of its length beyond CheckMinContentLength. Default: 20.
+	CheckMinScore float64
+	// Now returns the current time, used to decide how fresh the
+	// article is. Default: time.Now.
+	Now func() time.Time
+	// DateLocale is the language (e.g. "de" or "fr-FR") whose month and
+	// weekday names are understood when parsing dates, in addition to
+	// English. Supported: de, fr, es, it, pt and nl. Default: "", which
+	// uses the language declared by the document.
+	DateLocale string
+	// PreExtractHook is called with the working copy of document right
+	// before the content is grabbed, e.g. to unwrap a site-specific
+	// wrapper. The document passed to Parse or ParseDocument is never
+	// modified. Default: nil.
+	PreExtractHook func(*html.Node)
+	// PostExtractHook is called with the content node after it's been
+	// post-processed and before it's rendered into Article, e.g. to
+	// inject attribution. Default: nil.
+	PostExtractHook func(*html.Node)
+
+	parseState
 }
 
 // NewParser returns new Parser which set up with default value.
 func NewParser() Parser {
 	return Parser{
-		MaxElemsToParse:   0,
-		NTopCandidates:    5,
-		CharThresholds:    500,
-		ClassesToPreserve: []string{"page"},
-		KeepClasses:       false,
-		TagsToScore:       []string{"section", "h2", "h3", "h4", "h5", "h6", "p", "td", "pre"},
-		Debug:             false,
+		MaxElemsToParse:       0,
+		NTopCandidates:        defaultNTopCandidates,
+		CharThresholds:        defaultCharThresholds,
+		SiblingScoreThreshold: defaultSiblingScoreThreshold,
+		ClassesToPreserve:     []string{"page"},
+		KeepClasses:           false,
+		TagsToScore:           []string{"section", "h2", "h3", "h4", "h5", "h6", "p", "td", "pre", "dd"},
+		CandidateTags:         []string{"div"},
+		LazyImageAttributes:   []string{"data-src", "data-original", "data-lazy-src"},
+		AllowedEmbedHosts:     append([]string(nil), defaultAllowedEmbedHosts...),
+		TrackingParams:        append([]string(nil), defaultTrackingParams...),
+		RemoveShareButtons:    true,
+		DropAriaHidden:        true,
+		DetectCharset:         true,
+		Debug:                 false,
+		WordsPerMinute:        defaultWordsPerMinute,
+		CheckMinContentLength: defaultCheckMinContentLength,
+		CheckMinScore:         defaultCheckMinScore,
+		Now:                   time.Now,
 	}
 }
 
@@ -151,15 +717,209 @@ func (ps *Parser) postProcessContent(articleContent *html.Node) {
 	// Readability cannot open relative uris so we convert them to absolute uris.
 	ps.fixRelativeURIs(articleContent)
 
+	// Remove the noise of tracking parameters from links.
+	if ps.StripTrackingParams {
+		ps.stripTrackingParams(articleContent)
+	}
+
+	// Use the best image candidate of srcset.
+	if ps.ResolveSrcset {
+		ps.resolveSrcsets(articleContent)
+	}
+
+	// Normalize the languages of code blocks before their wrappers are
+	// simplified and the classes are removed.
+	if ps.ExtractCodeLanguages {
+		ps.normalizeCodeLanguages(articleContent)
+	}
+
+	// Only keep the structure of data tables.
+	if ps.UnwrapLayoutTables {
+		ps.unwrapLayoutTables(articleContent)
+	}
+
 	ps.simplifyNestedElements(articleContent)
 
+	// Keep the captions attached to their figures.
+	ps.normalizeFigures(articleContent)
+
+	// Set the missing size of images to prevent layout shift.
+	if ps.InferImageDimensions {
+		ps.inferImageDimensions(articleContent)
+	}
+
+	// Make heading levels contiguous.
+	if ps.NormalizeHeadingLevels {
+		ps.normalizeHeadingLevels(articleContent)
+	}
+
+	// Make emphasis tags consistent.
+	ps.normalizeEmphasisTags(articleContent)
+
+	// Add semantic classes for styling.
+	if ps.AnnotateOutput {
+		ps.annotateOutput(articleContent)
+	}
+
 	// Remove classes.
-	if !ps.KeepClasses {
+	if !ps.KeepClasses && !ps.isKeptAttribute("class") {
 		ps.cleanClasses(articleContent)
 	}
 
+	// Remove ids which aren't used as in-page anchors.
+	if ps.CleanIDs && !ps.isKeptAttribute("id") {
+		ps.cleanIDs(articleContent)
+	}
+
 	// Remove readability attributes.
 	ps.clearReadabilityAttr(articleContent)
+
+	// Apply user's tag allowlist and blocklist.
+	ps.filterTags(articleContent)
+}
+
+// filterTags removes elements whose tag listed in BlockedTags, then
+// unwraps elements whose tag isn't listed in AllowedTags (if it's
+// specified). The readability page container is always kept.
+func (ps *Parser) filterTags(articleContent *html.Node) {
+	for _, tag := range ps.BlockedTags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		ps.removeNodes(dom.GetElementsByTagName(articleContent, tag), nil)
+	}
+
+	if len(ps.AllowedTags) == 0 {
+		return
+	}
+
+	allowedTags := make(map[string]struct{})
+	for _, tag := range ps.AllowedTags {
+		allowedTags[strings.ToLower(strings.TrimSpace(tag))] = struct{}{}
+	}
+
+	// Traverse backwards so the children is unwrapped before its parent.
+	nodes := dom.GetElementsByTagName(articleContent, "*")
+	for i := len(nodes) - 1; i >= 0; i-- {
+		node := nodes[i]
+		if _, allowed := allowedTags[dom.TagName(node)]; allowed {
+			continue
+		}
+
+		if strings.HasPrefix(dom.ID(node), "readability-page") {
+			continue
+		}
+
+		ps.unwrapNode(node)
+	}
+}
+
+// unwrapNode replaces node with its children.
+func (ps *Parser) unwrapNode(node *html.Node) {
+	parent := node.Parent
+	if parent == nil {
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = node.FirstChild {
+		node.RemoveChild(child)
+		parent.InsertBefore(child, node)
+	}
+
+	parent.RemoveChild(node)
+}
+
+// getQuotes returns all block quotes in article content. The source is
+// taken from <cite> inside the quote, or from <figcaption> if the quote
+// is wrapped in <figure>. The source URL is taken from the cite attribute
+// of the quote, or from link inside the source.
+func (ps *Parser) getQuotes(articleContent *html.Node) []QuoteInfo {
+	var quotes []QuoteInfo
+	blockquotes := dom.GetElementsByTagName(articleContent, "blockquote")
+	ps.forEachNode(blockquotes, func(blockquote *html.Node, _ int) {
+		var source *html.Node
+		if cites := dom.GetElementsByTagName(blockquote, "cite"); len(cites) > 0 {
+			source = cites[0]
+		} else if parent := blockquote.Parent; parent != nil && dom.TagName(parent) == "figure" {
+			if captions := dom.GetElementsByTagName(parent, "figcaption"); len(captions) > 0 {
+				source = captions[0]
+			}
+		}
+
+		text := dom.TextContent(blockquote)
+		quote := QuoteInfo{SourceURL: dom.GetAttribute(blockquote, "cite")}
+		if source != nil {
+			quote.Source = ps.getInnerText(source, true)
+			if source.Parent == blockquote || ps.hasAncestorTag(source, "blockquote", -1, nil) {
+				text = strings.Replace(text, dom.TextContent(source), "", 1)
+			}
+
+			if quote.SourceURL == "" {
+				if links := dom.GetElementsByTagName(source, "a"); len(links) > 0 {
+					quote.SourceURL = dom.GetAttribute(links[0], "href")
+				}
+			}
+		}
+
+		quote.Text = strings.Join(strings.Fields(text), " ")
+		quote.Text = strings.TrimRight(quote.Text, " —–-")
+		if quote.Text != "" {
+			quotes = append(quotes, quote)
+		}
+	})
+	return quotes
+}
+
+// countMedia returns the number of images and videos in article
+// content. Embedded players (iframe, embed and object) are counted
+// as video only if they're from known video sites.
+func (ps *Parser) countMedia(articleContent *html.Node) (int, int) {
+	imageCount := len(dom.GetElementsByTagName(articleContent, "img"))
+	videoCount := len(dom.GetElementsByTagName(articleContent, "video"))
+
+	embeds := ps.getAllNodesWithTag(articleContent, "iframe", "embed", "object")
+	ps.forEachNode(embeds, func(embed *html.Node, _ int) {
+		if ps.isAllowedEmbed(embed) {
+			videoCount++
+		}
+	})
+
+	return imageCount, videoCount
+}
+
+// getHeaderImage returns the URL of image which opens the article
+// content, i.e. the image found before any text. Images which declared
+// smaller than 100px wide are skipped since they're likely icons.
+// Returns empty string if the content opens with text.
+func (ps *Parser) getHeaderImage(articleContent *html.Node) string {
+	var headerImage string
+	var finder func(*html.Node) bool
+
+	// finder returns true once either an image or text is found.
+	finder = func(node *html.Node) bool {
+		switch node.Type {
+		case html.TextNode:
+			return strings.TrimSpace(node.Data) != ""
+		case html.ElementNode:
+			if dom.TagName(node) == "img" {
+				width, err := strconv.Atoi(dom.GetAttribute(node, "width"))
+				if err == nil && width < 100 {
+					return false
+				}
+
+				headerImage = dom.GetAttribute(node, "src")
+				return headerImage != ""
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if finder(child) {
+				return true
+			}
+		}
+		return false
+	}
+
+	finder(articleContent)
+	return headerImage
 }
 
 // removeNodes iterates over a NodeList, calls `filterFn` for each node
@@ -249,7 +1009,11 @@ func (ps *Parser) cleanClasses(node *html.Node) {
 	nodeClassName := dom.ClassName(node)
 	preservedClassName := []string{}
 	for _, class := range strings.Fields(nodeClassName) {
-		if indexOf(ps.ClassesToPreserve, class) != -1 {
+		if indexOf(classesToPreserve, class) != -1 ||
+			indexOf(ps.ClassesToPreserve, class) != -1 ||
+			(ps.AnnotateOutput && strings.HasPrefix(class, outputClassPrefix)) ||
+			(ps.ExtractCodeLanguages && strings.HasPrefix(class, codeLanguageClassPrefix)) ||
+			ps.isCodeInPre(node) {
 			preservedClassName = append(preservedClassName, class)
 		}
 	}
@@ -265,8 +1029,77 @@ func (ps *Parser) cleanClasses(node *html.Node) {
 	}
 }
 
+// isKeptAttribute determines if the attribute is listed in KeepAttributes.
+func (ps *Parser) isKeptAttribute(name string) bool {
+	for _, kept := range ps.KeepAttributes {
+		if strings.EqualFold(strings.TrimSpace(kept), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanIDs removes id attributes in article content which not
+// referenced by the intra-document links in the content.
+func (ps *Parser) cleanIDs(articleContent *html.Node) {
+	referencedIDs := make(map[string]struct{})
+	links := ps.getAllNodesWithTag(articleContent, "a", "area")
+	ps.forEachNode(links, func(link *html.Node, _ int) {
+		if id := ps.getInPageAnchor(dom.GetAttribute(link, "href")); id != "" {
+			referencedIDs[id] = struct{}{}
+		}
+	})
+
+	nodes := append([]*html.Node{articleContent}, dom.GetElementsByTagName(articleContent, "*")...)
+	ps.forEachNode(nodes, func(node *html.Node, _ int) {
+		id := dom.ID(node)
+		if id == "" || strings.HasPrefix(id, "readability-page") {
+			return
+		}
+
+		if _, referenced := referencedIDs[id]; !referenced {
+			dom.RemoveAttribute(node, "id")
+		}
+	})
+}
+
+// getInPageAnchor returns the fragment of href if it points to
+// somewhere in the current document, e.g. "#step-3" or the page URL
+// followed by "#step-3". Returns empty string otherwise.
+func (ps *Parser) getInPageAnchor(href string) string {
+	href = strings.TrimSpace(href)
+	if strings.HasPrefix(href, "#") {
+		fragment, err := nurl.PathUnescape(href[1:])
+		if err != nil {
+			return href[1:]
+		}
+		return fragment
+	}
+
+	if ps.documentURI == nil {
+		return ""
+	}
+
+	url, err := nurl.Parse(href)
+	if err != nil || url.Fragment == "" {
+		return ""
+	}
+
+	fragment := url.Fragment
+	pageURL := *ps.documentURI
+	pageURL.Fragment = ""
+	url.Fragment = ""
+	if url.String() != pageURL.String() {
+		return ""
+	}
+
+	return fragment
+}
+
 // fixRelativeURIs converts each <a> and <img> uri in the given element
-// to an absolute URI, ignoring #ref URIs.
+// to an absolute URI, ignoring #ref URIs. URIs that able to run script
+// (javascript:, vbscript: and data: which isn't raster image) are
+// neutralized, including the ones in area, form and embedded elements.
 func (ps *Parser) fixRelativeURIs(articleContent *html.Node) {
 	links := ps.getAllNodesWithTag(articleContent, "a")
 	ps.forEachNode(links, func(link *html.Node, _ int) {
@@ -276,8 +1109,10 @@ func (ps *Parser) fixRelativeURIs(articleContent *html.Node) {
 		}
 
 		// Remove links with javascript: URIs, since they won't
-		// work after scripts have been removed from the page.
-		if strings.HasPrefix(href, "javascript:") {
+		// work after scripts have been removed from the page. The
+		// same goes for other scripting URIs, which are dangerous
+		// when the content is rendered.
+		if isUnsafeURL(href) {
 			linkChilds := dom.ChildNodes(link)
 
 			if len(linkChilds) == 1 && linkChilds[0].Type == html.TextNode {
@@ -304,18 +1139,29 @@ func (ps *Parser) fixRelativeURIs(articleContent *html.Node) {
 		}
 	})
 
+	quotes := ps.getAllNodesWithTag(articleContent, "blockquote", "q", "del", "ins")
+	ps.forEachNode(quotes, func(quote *html.Node, _ int) {
+		if cite := dom.GetAttribute(quote, "cite"); cite != "" {
+			dom.SetAttribute(quote, "cite", toAbsoluteURI(cite, ps.documentURI))
+		}
+	})
+
 	medias := ps.getAllNodesWithTag(articleContent, "img", "picture", "figure", "video", "audio", "source")
 	ps.forEachNode(medias, func(media *html.Node, _ int) {
 		src := dom.GetAttribute(media, "src")
 		poster := dom.GetAttribute(media, "poster")
 		srcset := dom.GetAttribute(media, "srcset")
 
-		if src != "" {
+		if src != "" && isUnsafeURL(src) {
+			dom.RemoveAttribute(media, "src")
+		} else if src != "" {
 			newSrc := toAbsoluteURI(src, ps.documentURI)
 			dom.SetAttribute(media, "src", newSrc)
 		}
 
-		if poster != "" {
+		if poster != "" && isUnsafeURL(poster) {
+			dom.RemoveAttribute(media, "poster")
+		} else if poster != "" {
 			newPoster := toAbsoluteURI(poster, ps.documentURI)
 			dom.SetAttribute(media, "poster", newPoster)
 		}
@@ -329,6 +1175,46 @@ func (ps *Parser) fixRelativeURIs(articleContent *html.Node) {
 			dom.SetAttribute(media, "srcset", newSrcset)
 		}
 	})
+
+	// Image maps and forms navigate the page like links do.
+	areas := ps.getAllNodesWithTag(articleContent, "area")
+	ps.forEachNode(areas, func(area *html.Node, _ int) {
+		ps.fixURIAttribute(area, "href")
+	})
+
+	forms := ps.getAllNodesWithTag(articleContent, "form")
+	ps.forEachNode(forms, func(form *html.Node, _ int) {
+		ps.fixURIAttribute(form, "action")
+	})
+
+	submits := ps.getAllNodesWithTag(articleContent, "button", "input")
+	ps.forEachNode(submits, func(submit *html.Node, _ int) {
+		ps.fixURIAttribute(submit, "formaction")
+	})
+
+	// Embedded documents with scripting URIs would run the script
+	// when rendered, so remove them entirely.
+	embeds := ps.getAllNodesWithTag(articleContent, "iframe", "frame", "embed", "object")
+	ps.removeNodes(embeds, func(embed *html.Node) bool {
+		return isUnsafeEmbedURL(dom.GetAttribute(embed, "src")) ||
+			isUnsafeEmbedURL(dom.GetAttribute(embed, "data"))
+	})
+}
+
+// fixURIAttribute converts the URI in attribute of node to an absolute
+// URI, or removes the attribute if its URI is able to run script.
+func (ps *Parser) fixURIAttribute(node *html.Node, attrName string) {
+	uri := dom.GetAttribute(node, attrName)
+	if uri == "" {
+		return
+	}
+
+	newURI := toAbsoluteURI(uri, ps.documentURI)
+	if newURI == "" || isUnsafeURL(uri) {
+		dom.RemoveAttribute(node, attrName)
+	} else {
+		dom.SetAttribute(node, attrName, newURI)
+	}
 }
 
 func (ps *Parser) simplifyNestedElements(articleContent *html.Node) {
@@ -454,6 +1340,27 @@ func (ps *Parser) prepDocument() {
 	}
 
 	ps.replaceNodeTags(dom.GetElementsByTagName(doc, "font"), "span")
+
+	if ps.DropAriaHidden {
+		ps.removeAriaHidden(doc)
+	}
+}
+
+// removeAriaHidden removes elements which hidden from screen readers
+// using aria-hidden="true", since they are explicitly decorative (e.g.
+// icon ligatures and separators). Some sites put aria-hidden on wrapper
+// whose content is still visible, so element that contains any block
+// or long text is kept, just like Wikimedia's fallback images.
+func (ps *Parser) removeAriaHidden(doc *html.Node) {
+	ps.removeNodes(dom.GetElementsByTagName(doc, "*"), func(node *html.Node) bool {
+		if !strings.EqualFold(strings.TrimSpace(dom.GetAttribute(node, "aria-hidden")), "true") ||
+			strings.Contains(dom.ClassName(node), "fallback-image") {
+			return false
+		}
+
+		return !ps.hasChildBlockElement(node) &&
+			charCount(ps.getInnerText(node, true)) < 25
+	})
 }
 
 // nextNode finds the next element, starting from the given node, and
@@ -559,7 +1466,11 @@ func (ps *Parser) prepArticle(articleContent *html.Node) {
 	ps.clean(articleContent, "object")
 	ps.clean(articleContent, "embed")
 	ps.clean(articleContent, "h1")
-	ps.clean(articleContent, "footer")
+	ps.removeNodes(dom.GetElementsByTagName(articleContent, "footer"), func(footer *html.Node) bool {
+		// Footer inside block quote is commonly used for the quote's
+		// attribution, so keep it.
+		return !ps.hasAncestorTag(footer, "blockquote", 3, nil)
+	})
 	ps.clean(articleContent, "link")
 	ps.clean(articleContent, "aside")
 
@@ -608,6 +1519,12 @@ func (ps *Parser) prepArticle(articleContent *html.Node) {
 	ps.cleanConditionally(articleContent, "ul")
 	ps.cleanConditionally(articleContent, "div")
 
+	// Remove clusters of share buttons which survived, e.g. the ones
+	// placed between paragraphs without any share class name.
+	if ps.RemoveShareButtons {
+		ps.removeShareButtons(articleContent)
+	}
+
 	// Remove extra paragraphs
 	ps.removeNodes(dom.GetElementsByTagName(articleContent, "p"), func(p *html.Node) bool {
 		imgCount := len(dom.GetElementsByTagName(p, "img"))
@@ -621,6 +1538,11 @@ func (ps *Parser) prepArticle(articleContent *html.Node) {
 		return totalCount == 0 && ps.getInnerText(p, false) == ""
 	})
 
+	// Remove definition lists that have no term or definition left
+	ps.removeNodes(dom.GetElementsByTagName(articleContent, "dl"), func(dl *html.Node) bool {
+		return ps.getInnerText(dl, false) == "" && len(ps.getAllNodesWithTag(dl, "img", "picture", "video", "iframe")) == 0
+	})
+
 	ps.forEachNode(dom.GetElementsByTagName(articleContent, "br"), func(br *html.Node, _ int) {
 		next := ps.nextNode(br.NextSibling)
 		if next != nil && dom.TagName(next) == "p" {
@@ -652,21 +1574,55 @@ func (ps *Parser) prepArticle(articleContent *html.Node) {
 	})
 }
 
+// limitSiblings returns the siblings of top candidate which will be
+// considered for merging. If MaxCandidates is set and there are too many
+// siblings (e.g. flat list of thousand comments), only the ones closest
+// to top candidate are returned.
+func (ps *Parser) limitSiblings(siblings []*html.Node, topCandidate *html.Node) []*html.Node {
+	if ps.MaxCandidates <= 0 || len(siblings) <= ps.MaxCandidates {
+		return siblings
+	}
+
+	idx := 0
+	for i, sibling := range siblings {
+		if sibling == topCandidate {
+			idx = i
+			break
+		}
+	}
+
+	start := idx - ps.MaxCandidates/2
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + ps.MaxCandidates
+	if end > len(siblings) {
+		end = len(siblings)
+		start = end - ps.MaxCandidates
+	}
+
+	return siblings[start:end]
+}
+
 // initializeNode initializes a node with the readability score.
 // Also checks the className/id for special names to add to its score.
 func (ps *Parser) initializeNode(node *html.Node) {
-	contentScore := float64(ps.getClassWeight(node))
-	switch dom.TagName(node) {
-	case "div":
-		contentScore += 5
-	case "pre", "td", "blockquote":
-		contentScore += 3
-	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
-		contentScore -= 3
-	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
-		contentScore -= 5
+	candidateTags := ps.CandidateTags
+	if len(candidateTags) == 0 {
+		candidateTags = defaultCandidateTags
 	}
 
+	tagName := dom.TagName(node)
+	tagWeight, customized := ps.TagWeights[tagName]
+	if !customized {
+		tagWeight = defaultTagWeights[tagName]
+		if indexOf(candidateTags, tagName) != -1 {
+			tagWeight += 5
+		}
+	}
+
+	contentScore := float64(ps.getClassWeight(node) + tagWeight)
 	ps.setContentScore(node, contentScore)
 }
 
@@ -727,6 +1683,13 @@ func (ps *Parser) checkByline(node *html.Node, matchString string) bool {
 		nodeText = strings.TrimSpace(nodeText)
 		nodeText = strings.Join(strings.Fields(nodeText), " ")
 		ps.articleByline = nodeText
+
+		// Byline often has the author's avatar as well
+		if imgs := dom.GetElementsByTagName(node, "img"); len(imgs) > 0 {
+			ps.articleBylineImage = strOr(
+				dom.GetAttribute(imgs[0], "src"),
+				dom.GetAttribute(imgs[0], "data-src"))
+		}
 		return true
 	}
 
@@ -755,6 +1718,10 @@ func (ps *Parser) getNodeAncestors(node *html.Node, maxDepth int) []*html.Node {
 // stuff a user wants to read. Then return it wrapped up in a div.
 func (ps *Parser) grabArticle() *html.Node {
 	for {
+		if ps.canceled() {
+			return nil
+		}
+
 		doc := dom.Clone(ps.doc, true)
 
 		var page *html.Node
@@ -775,6 +1742,10 @@ func (ps *Parser) grabArticle() *html.Node {
 		var node = dom.DocumentElement(doc)
 
 		for node != nil {
+			if ps.canceled() {
+				return nil
+			}
+
 			matchString := dom.ClassName(node) + " " + dom.ID(node)
 
 			if !ps.isProbablyVisible(node) {
@@ -935,6 +1906,15 @@ func (ps *Parser) grabArticle() *html.Node {
 		// So, here we simply sort top candidates, and limit it to
 		// max NTopCandidates.
 
+		// On huge pages, only keep the best candidates before scaling
+		// their score, since computing link density is expensive.
+		if ps.MaxCandidates > 0 && len(candidates) > ps.MaxCandidates {
+			sort.SliceStable(candidates, func(i int, j int) bool {
+				return ps.getContentScore(candidates[i]) > ps.getContentScore(candidates[j])
+			})
+			candidates = candidates[:ps.MaxCandidates]
+		}
+
 		// Scale the final candidates score based on link density. Good
 		// content should have a relatively small link density (5% or
 		// less) and be mostly unaffected by this operation.
@@ -950,9 +1930,14 @@ func (ps *Parser) grabArticle() *html.Node {
 			return ps.getContentScore(candidates[i]) > ps.getContentScore(candidates[j])
 		})
 
+		nTopCandidates := ps.NTopCandidates
+		if nTopCandidates <= 0 {
+			nTopCandidates = defaultNTopCandidates
+		}
+
 		var topCandidates []*html.Node
-		if len(candidates) > ps.NTopCandidates {
-			topCandidates = candidates[:ps.NTopCandidates]
+		if len(candidates) > nTopCandidates {
+			topCandidates = candidates[:nTopCandidates]
 		} else {
 			topCandidates = candidates
 		}
@@ -1068,14 +2053,22 @@ func (ps *Parser) grabArticle() *html.Node {
 		// for content that might also be related. Things like preambles,
 		// content split by ads that we removed, etc.
 		articleContent := dom.CreateElement("div")
-		siblingScoreThreshold := math.Max(10, ps.getContentScore(topCandidate)*0.2)
+		siblingScoreFactor := ps.SiblingScoreThreshold
+		if siblingScoreFactor <= 0 {
+			siblingScoreFactor = defaultSiblingScoreThreshold
+		}
+		siblingScoreThreshold := math.Max(10, ps.getContentScore(topCandidate)*siblingScoreFactor)
+
+		rootSelectionMethod := ps.getRootSelectionMethod(topCandidate, neededToCreateTopCandidate)
+		topCandidateClass := dom.ClassName(topCandidate) + " " + dom.ID(topCandidate)
 
 		// Keep potential top candidate's parent node to try to get text direction of it later.
 		topCandidateScore := ps.getContentScore(topCandidate)
 		topCandidateClassName := dom.ClassName(topCandidate)
+		topCandidateTagName := dom.TagName(topCandidate)
 
 		parentOfTopCandidate = topCandidate.Parent
-		siblings := dom.Children(parentOfTopCandidate)
+		siblings := ps.limitSiblings(dom.Children(parentOfTopCandidate), topCandidate)
 		for s := 0; s < len(siblings); s++ {
 			sibling := siblings[s]
 			appendNode := false
@@ -1142,9 +2135,20 @@ func (ps *Parser) grabArticle() *html.Node {
 			}
 		} else {
 			div := dom.CreateElement("div")
+			childs := dom.ChildNodes(articleContent)
+
+			// If the top candidate is a semantic container and there are
+			// no siblings merged into it, use it as the page container.
+			if ps.PreserveRootTag && indexOf(preservedRootTags, topCandidateTagName) != -1 {
+				if elementChilds := dom.Children(articleContent); len(elementChilds) == 1 && elementChilds[0] == topCandidate {
+					div = dom.CreateElement(topCandidateTagName)
+					childs = dom.ChildNodes(topCandidate)
+					articleContent.RemoveChild(topCandidate)
+				}
+			}
+
 			dom.SetAttribute(div, "id", "readability-page-1")
 			dom.SetAttribute(div, "class", "page")
-			childs := dom.ChildNodes(articleContent)
 			for i := 0; i < len(childs); i++ {
 				dom.AppendChild(div, childs[i])
 			}
@@ -1160,50 +2164,46 @@ func (ps *Parser) grabArticle() *html.Node {
 		// the sieve approach gives us a higher likelihood of
 		// finding the -right- content.
 		textLength := charCount(ps.getInnerText(articleContent, true))
+		ps.attempts = append(ps.attempts, parseAttempt{
+			articleContent:    articleContent,
+			textLength:        textLength,
+			flags:             ps.flags,
+			topCandidateClass: topCandidateClass,
+		})
+
 		if textLength < ps.CharThresholds {
 			parseSuccessful = false
 
 			if ps.flags.stripUnlikelys {
 				ps.flags.stripUnlikelys = false
-				ps.attempts = append(ps.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
 			} else if ps.flags.useWeightClasses {
 				ps.flags.useWeightClasses = false
-				ps.attempts = append(ps.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
 			} else if ps.flags.cleanConditionally {
 				ps.flags.cleanConditionally = false
-				ps.attempts = append(ps.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
 			} else {
-				ps.attempts = append(ps.attempts, parseAttempt{
-					articleContent: articleContent,
-					textLength:     textLength,
-				})
-
 				// No luck after removing flags, just return the
-				// longest text we found during the different loops *
-				sort.Slice(ps.attempts, func(i, j int) bool {
-					return ps.attempts[i].textLength > ps.attempts[j].textLength
+				// longest text we found during the different loops *.
+				// Sort a copy, so the attempts are kept in order.
+				longest := append([]parseAttempt(nil), ps.attempts...)
+				sort.Slice(longest, func(i, j int) bool {
+					return longest[i].textLength > longest[j].textLength
 				})
 
 				// But first check if we actually have something
-				if ps.attempts[0].textLength == 0 {
+				if longest[0].textLength == 0 {
 					return nil
 				}
 
-				articleContent = ps.attempts[0].articleContent
+				articleContent = longest[0].articleContent
+				topCandidateClass = longest[0].topCandidateClass
+				rootSelectionMethod = RootSelectionLongestAttempt
 				parseSuccessful = true
 			}
 		}
 
 		if parseSuccessful {
+			ps.rootSelectionMethod = rootSelectionMethod
+			ps.topCandidateClass = topCandidateClass
 			return articleContent
 		}
 	}
@@ -1218,64 +2218,56 @@ func (ps *Parser) isValidByline(byline string) bool {
 	return nChar > 0 && nChar < 100
 }
 
-// getJSONLD try to extract metadata from JSON-LD object.
-// For now, only Schema.org objects of type Article or its subtypes are supported.
+// getJSONLD try to extract metadata from JSON-LD object. Every JSON-LD
+// script in the document is searched, including the arrays and @graph
+// inside them, and the first article found is used. For now, only
+// Schema.org objects of type Article or its subtypes are supported.
 func (ps *Parser) getJSONLD() (map[string]string, error) {
-	// Find and extract <script> with type "application/ld+json"
-	scripts := ps.getAllNodesWithTag(ps.doc, "script")
-	jsonLdElement := ps.findNode(scripts, func(n *html.Node) bool {
-		return dom.GetAttribute(n, "type") == "application/ld+json"
-	})
+	// Find the article in every <script> with type "application/ld+json",
+	// skipping the ones that can't be decoded.
+	var parsed, video map[string]interface{}
+	var strType string
+	var decodeErr error
 
-	if jsonLdElement == nil {
-		return nil, nil
-	}
-
-	// Strip CDATA markers if present
-	content := rxCDATA.ReplaceAllString(dom.TextContent(jsonLdElement), "")
-
-	// Decode JSON
-	var parsed map[string]interface{}
-	err := json.Unmarshal([]byte(content), &parsed)
-	if err != nil {
-		return nil, err
-	}
+	scripts := ps.getAllNodesWithTag(ps.doc, "script")
+	for _, script := range scripts {
+		if dom.GetAttribute(script, "type") != "application/ld+json" {
+			continue
+		}
 
-	// Check context
-	strContext, isString := parsed["@context"].(string)
-	if !isString || !rxSchemaOrg.MatchString(strContext) {
-		return nil, nil
-	}
+		// Strip CDATA markers if present
+		content := rxCDATA.ReplaceAllString(dom.TextContent(script), "")
 
-	// If parsed doesn't have any @type, find it in its graph list
-	if _, typeExist := parsed["@type"]; !typeExist {
-		graphList, isArray := parsed["@graph"].([]interface{})
-		if !isArray {
-			return nil, nil
+		// Decode JSON
+		var value interface{}
+		if err := json.Unmarshal([]byte(content), &value); err != nil {
+			if decodeErr == nil {
+				decodeErr = err
+			}
+			continue
 		}
 
-		for _, graph := range graphList {
-			objGraph, isObj := graph.(map[string]interface{})
-			if !isObj {
-				continue
-			}
+		// Video-led page might only declare a standalone VideoObject,
+		// so keep it for the thumbnail.
+		if video == nil {
+			video = findJSONLDVideo(value)
+		}
 
-			strType, isString := objGraph["@type"].(string)
-			if isString && rxJsonLdArticleTypes.MatchString(strType) {
-				parsed = objGraph
-				break
-			}
+		if parsed, strType = findJSONLDArticle(value, false); parsed != nil {
+			break
 		}
 	}
 
-	// Once again, make sure parsed has valid @type
-	strType, isString := parsed["@type"].(string)
-	if !isString || !rxJsonLdArticleTypes.MatchString(strType) {
-		return nil, nil
+	if parsed == nil {
+		if thumbnail := ps.getJSONLDVideoThumbnail(video); thumbnail != "" {
+			return map[string]string{"videoThumbnail": thumbnail}, nil
+		}
+		return nil, decodeErr
 	}
 
 	// Fetch metadata
 	metadata := make(map[string]string)
+	metadata["type"] = strType
 
 	// Title
 	if name, isString := parsed["name"].(string); isString {
@@ -1285,26 +2277,10 @@ func (ps *Parser) getJSONLD() (map[string]string, error) {
 	}
 
 	// Author
-	switch val := parsed["author"].(type) {
-	case map[string]interface{}:
-		if name, isString := val["name"].(string); isString {
-			metadata["byline"] = strings.TrimSpace(name)
-		}
-
-	case []interface{}:
-		var authors []string
-		for _, author := range val {
-			objAuthor, isObj := author.(map[string]interface{})
-			if !isObj {
-				continue
-			}
-
-			if name, isString := objAuthor["name"].(string); isString {
-				authors = append(authors, strings.TrimSpace(name))
-			}
-		}
-		metadata["byline"] = strings.Join(authors, ", ")
-	}
+	authors, authorImage := ps.getJSONLDAuthors(parsed["author"])
+	metadata["byline"] = strings.Join(authors, ", ")
+	metadata["authors"] = strings.Join(authors, "\n")
+	metadata["authorImage"] = authorImage
 
 	// Description
 	if description, isString := parsed["description"].(string); isString {
@@ -1316,8 +2292,14 @@ func (ps *Parser) getJSONLD() (map[string]string, error) {
 		if name, isString := objPublisher["name"].(string); isString {
 			metadata["siteName"] = strings.TrimSpace(name)
 		}
+
+		metadata["publisherLogo"] = ps.getJSONLDImageURL(objPublisher["logo"])
 	}
 
+	// Image of the article and its credit
+	metadata["image"] = ps.getJSONLDImage(parsed["image"])
+	metadata["imageCreditURL"], metadata["imageCredit"] = ps.getJSONLDImageCredit(parsed["image"])
+
 	if datePublished, isString := parsed["datePublished"].(string); isString {
 		metadata["datePublished"] = strings.TrimSpace(datePublished)
 	}
@@ -1327,17 +2309,252 @@ func (ps *Parser) getJSONLD() (map[string]string, error) {
 		metadata["dateModified"] = strings.TrimSpace(dateModified)
 	}
 
+	switch commentCount := parsed["commentCount"].(type) {
+	case float64:
+		metadata["commentCount"] = strconv.Itoa(int(commentCount))
+	case string:
+		metadata["commentCount"] = strings.TrimSpace(commentCount)
+	}
+
+	switch wordCount := parsed["wordCount"].(type) {
+	case float64:
+		metadata["wordCount"] = strconv.Itoa(int(wordCount))
+	case string:
+		metadata["wordCount"] = strings.TrimSpace(wordCount)
+	}
+
+	metadata["videoThumbnail"] = strOr(
+		ps.getJSONLDVideoThumbnail(parsed["video"]),
+		ps.getJSONLDVideoThumbnail(video))
+
+	if sponsor, exist := parsed["sponsor"]; exist && sponsor != nil {
+		metadata["sponsored"] = "true"
+	}
+
+	// Keywords might be a comma separated string or list of strings.
+	switch keywords := parsed["keywords"].(type) {
+	case string:
+		metadata["keywords"] = keywords
+	case []interface{}:
+		var strKeywords []string
+		for _, keyword := range keywords {
+			if strKeyword, isString := keyword.(string); isString {
+				strKeywords = append(strKeywords, strKeyword)
+			}
+		}
+		metadata["keywords"] = strings.Join(strKeywords, ",")
+	}
+
+	if timeRequired, isString := parsed["timeRequired"].(string); isString {
+		metadata["timeRequired"] = strings.TrimSpace(timeRequired)
+	}
+
 	return metadata, nil
 }
 
+// findJSONLDArticle finds the first article object in JSON-LD value,
+// which might be an array of objects or a graph, and returns it with its
+// article type. Objects in array and graph inherit the @context of their
+// container, and only the ones in schema.org context are accepted.
+func findJSONLDArticle(value interface{}, inSchemaOrg bool) (map[string]interface{}, string) {
+	switch val := value.(type) {
+	case []interface{}:
+		for _, item := range val {
+			if objArticle, strType := findJSONLDArticle(item, inSchemaOrg); objArticle != nil {
+				return objArticle, strType
+			}
+		}
+
+	case map[string]interface{}:
+		if objContext, exist := val["@context"]; exist {
+			inSchemaOrg = isJSONLDSchemaOrg(objContext)
+		}
+
+		if !inSchemaOrg {
+			return nil, ""
+		}
+
+		if strType := getJSONLDArticleType(val["@type"]); strType != "" {
+			return val, strType
+		}
+		return findJSONLDArticle(val["@graph"], inSchemaOrg)
+	}
+
+	return nil, ""
+}
+
+// isJSONLDSchemaOrg determines if the JSON-LD @context, which might be a
+// single context or list of contexts, is schema.org.
+func isJSONLDSchemaOrg(objContext interface{}) bool {
+	switch val := objContext.(type) {
+	case string:
+		return rxSchemaOrg.MatchString(val)
+	case []interface{}:
+		for _, item := range val {
+			if isJSONLDSchemaOrg(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getJSONLDArticleType returns the article type within the JSON-LD @type,
+// which might be a single type or list of types.
+func getJSONLDArticleType(objType interface{}) string {
+	switch val := objType.(type) {
+	case string:
+		if rxJsonLdArticleTypes.MatchString(val) {
+			return val
+		}
+	case []interface{}:
+		for _, item := range val {
+			if strType := getJSONLDArticleType(item); strType != "" {
+				return strType
+			}
+		}
+	}
+	return ""
+}
+
+// getJSONLDAuthors returns the names of authors in JSON-LD, which might
+// be declared as plain name, Person object, @graph of Person, or list
+// of those. Also returns the image of the first author that has one.
+func (ps *Parser) getJSONLDAuthors(author interface{}) ([]string, string) {
+	var authors []string
+	var authorImage string
+	seen := make(map[string]struct{})
+
+	var collect func(interface{})
+	collect = func(value interface{}) {
+		switch val := value.(type) {
+		case string:
+			name := strings.TrimSpace(val)
+			if _, isSeen := seen[name]; name != "" && !isSeen {
+				seen[name] = struct{}{}
+				authors = append(authors, name)
+			}
+
+		case []interface{}:
+			for _, item := range val {
+				collect(item)
+			}
+
+		case map[string]interface{}:
+			if graph, isArray := val["@graph"].([]interface{}); isArray {
+				collect(graph)
+				return
+			}
+
+			if name, isString := val["name"].(string); isString {
+				collect(name)
+			}
+
+			if authorImage == "" {
+				authorImage = ps.getJSONLDImageURL(val["image"])
+			}
+		}
+	}
+
+	collect(author)
+	return authors, authorImage
+}
+
+// getJSONLDImageURL returns URL of an image in JSON-LD, which might be
+// declared as a plain URL string or as an ImageObject.
+func (ps *Parser) getJSONLDImageURL(image interface{}) string {
+	switch val := image.(type) {
+	case string:
+		return strings.TrimSpace(val)
+	case map[string]interface{}:
+		if url, isString := val["url"].(string); isString {
+			return strings.TrimSpace(url)
+		}
+		if url, isString := val["contentUrl"].(string); isString {
+			return strings.TrimSpace(url)
+		}
+	}
+	return ""
+}
+
+// getJSONLDImage returns URL of the article's image in JSON-LD, which
+// might be declared as a plain URL, an ImageObject, or list of those. If
+// there are several images, the largest one is used, or the first one
+// if their sizes are unknown.
+func (ps *Parser) getJSONLDImage(image interface{}) string {
+	list, isArray := image.([]interface{})
+	if !isArray {
+		return ps.getJSONLDImageURL(image)
+	}
+
+	var bestURL string
+	bestArea := -1
+	for _, item := range list {
+		url := ps.getJSONLDImageURL(item)
+		if url == "" {
+			continue
+		}
+
+		area := 0
+		if objImage, isObj := item.(map[string]interface{}); isObj {
+			area = getJSONLDDimension(objImage["width"]) * getJSONLDDimension(objImage["height"])
+		}
+
+		if area > bestArea {
+			bestURL, bestArea = url, area
+		}
+	}
+
+	return bestURL
+}
+
+// getJSONLDDimension returns the width or height of ImageObject, which
+// might be declared as number, numeric string, or QuantitativeValue.
+func getJSONLDDimension(dimension interface{}) int {
+	switch val := dimension.(type) {
+	case float64:
+		return int(val)
+	case string:
+		number, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(val), "px"))
+		return number
+	case map[string]interface{}:
+		return getJSONLDDimension(val["value"])
+	}
+	return 0
+}
+
+// getJSONLDImageCredit returns the URL and creditText of the image in
+// JSON-LD. If there are several images, the first one which has credit
+// is used.
+func (ps *Parser) getJSONLDImageCredit(image interface{}) (string, string) {
+	switch val := image.(type) {
+	case map[string]interface{}:
+		if credit, isString := val["creditText"].(string); isString && strings.TrimSpace(credit) != "" {
+			return ps.getJSONLDImageURL(val), strings.TrimSpace(credit)
+		}
+	case []interface{}:
+		for _, item := range val {
+			if url, credit := ps.getJSONLDImageCredit(item); credit != "" {
+				return url, credit
+			}
+		}
+	}
+	return "", ""
+}
+
 // getArticleMetadata attempts to get excerpt and byline
 // metadata for the article.
 func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string {
 	values := make(map[string]string)
 	metaElements := dom.GetElementsByTagName(ps.doc, "meta")
+	var articleTags, metaKeywords []string
 
 	// Find description tags.
 	ps.forEachNode(metaElements, func(element *html.Node, _ int) {
+		if ps.canceled() {
+			return
+		}
+
 		elementName := dom.GetAttribute(element, "name")
 		elementProperty := dom.GetAttribute(element, "property")
 		content := dom.GetAttribute(element, "content")
@@ -1347,6 +2564,30 @@ func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string
 		if elementProperty == "article:published_time" {
 			values["datePublished"] = content
 		}
+
+		if elementProperty == "article:modified_time" {
+			values["dateModified"] = content
+		}
+
+		if elementProperty == "article:tag" {
+			articleTags = append(articleTags, content)
+		}
+
+		if lowerName := strings.ToLower(elementName); lowerName == "keywords" || lowerName == "news_keywords" {
+			metaKeywords = append(metaKeywords, content)
+		}
+
+		if strings.ToLower(elementName) == "theme-color" {
+			media := strings.ToLower(dom.GetAttribute(element, "media"))
+			switch {
+			case strings.Contains(media, "dark"):
+				values["theme-color:dark"] = content
+			case strings.Contains(media, "light"):
+				values["theme-color:light"] = content
+			default:
+				values["theme-color"] = content
+			}
+		}
 		matches := []string{}
 		name := ""
 
@@ -1357,6 +2598,11 @@ func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string
 				// so we can match belops.
 				name = strings.ToLower(matches[i])
 				name = strings.Join(strings.Fields(name), "")
+				// Pages might declare several images, the first one
+				// is the main image.
+				if strings.Contains(name, ":image") && values[name] != "" {
+					continue
+				}
 				// multiple authors
 				values[name] = strings.TrimSpace(content)
 			}
@@ -1378,10 +2624,10 @@ func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string
 		values["dc:title"],
 		values["dcterm:title"],
 		values["og:title"],
+		values["twitter:title"],
 		values["weibo:article:title"],
 		values["weibo:webpage:title"],
-		values["title"],
-		values["twitter:title"])
+		values["title"])
 
 	if metadataTitle == "" {
 		metadataTitle = ps.getArticleTitle()
@@ -1400,10 +2646,10 @@ func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string
 		values["dc:description"],
 		values["dcterm:description"],
 		values["og:description"],
+		values["twitter:description"],
 		values["weibo:article:description"],
 		values["weibo:webpage:description"],
-		values["description"],
-		values["twitter:description"])
+		values["description"])
 
 	// get site name
 	metadataSiteName := strOr(jsonLd["siteName"], values["og:site_name"])
@@ -1411,18 +2657,48 @@ func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string
 	// get image thumbnail
 	metadataImage := strOr(
 		values["og:image"],
+		values["og:image:url"],
+		values["og:image:secure_url"],
+		values["twitter:image"],
+		values["twitter:image:src"],
 		values["image"],
-		values["twitter:image"])
+		jsonLd["image"])
+	metadataImage = toAbsoluteURI(metadataImage, ps.documentURI)
 
 	// get favicon
 	metadataFavicon := ps.getArticleFavicon()
 
+	// get URL of AMP version
+	metadataAMPURL := ps.getArticleAMPURL()
+
+	// get canonical URL
+	metadataCanonicalURL := ps.getArticleCanonicalURL(values["og:url"])
+
+	// get author's image
+	metadataAuthorImage := toAbsoluteURI(jsonLd["authorImage"], ps.documentURI)
+
+	// get thumbnail of the article's video
+	metadataVideoThumbnail := toAbsoluteURI(jsonLd["videoThumbnail"], ps.documentURI)
+
+	// get publisher logo
+	metadataPublisherLogo := strOr(jsonLd["publisherLogo"], values["og:logo"])
+	metadataPublisherLogo = toAbsoluteURI(metadataPublisherLogo, ps.documentURI)
+
+	// get dates, the <time> elements are used as the last resort
+	timePublished, timeModified := ps.getTimeElementDates()
 	metadataDatePublished := strOr(
 		jsonLd["datePublished"],
 		values["dcterms.available"],
 		values["dcterms.created"],
-		values["dcterms.issued"], values["datePublished"])
-	metadataDateModified := strOr(jsonLd["dateModified"], values["dcterms.modified"])
+		values["dcterms.issued"], values["datePublished"], timePublished)
+	metadataDateModified := strOr(jsonLd["dateModified"], values["dcterms.modified"], values["dateModified"], timeModified)
+
+	// get theme color, the light one is preferred
+	metadataThemeColor := normalizeColor(strOr(values["theme-color:light"], values["theme-color"]))
+	metadataThemeColorDark := normalizeColor(values["theme-color:dark"])
+
+	// get reading time declared by publisher
+	metadataReadingTime, metadataReadingTimeSource := ps.getDeclaredReadingTime(jsonLd)
 
 	// in many sites the meta value is escaped with HTML entities,
 	// so here we need to unescape it
@@ -1434,14 +2710,31 @@ func (ps *Parser) getArticleMetadata(jsonLd map[string]string) map[string]string
 	metadataDateModified = shtml.UnescapeString(metadataDateModified)
 
 	return map[string]string{
-		"title":         metadataTitle,
-		"byline":        metadataByline,
-		"excerpt":       metadataExcerpt,
-		"siteName":      metadataSiteName,
-		"image":         metadataImage,
-		"favicon":       metadataFavicon,
-		"datePublished": metadataDatePublished,
-		"dateModified":  metadataDateModified,
+		"title":             metadataTitle,
+		"byline":            metadataByline,
+		"excerpt":           metadataExcerpt,
+		"siteName":          metadataSiteName,
+		"image":             metadataImage,
+		"favicon":           metadataFavicon,
+		"ampURL":            metadataAMPURL,
+		"canonicalURL":      metadataCanonicalURL,
+		"publisherLogo":     metadataPublisherLogo,
+		"authorImage":       metadataAuthorImage,
+		"imageCredit":       jsonLd["imageCredit"],
+		"imageCreditURL":    toAbsoluteURI(jsonLd["imageCreditURL"], ps.documentURI),
+		"videoThumbnail":    metadataVideoThumbnail,
+		"datePublished":     metadataDatePublished,
+		"dateModified":      metadataDateModified,
+		"type":              jsonLd["type"],
+		"themeColor":        metadataThemeColor,
+		"themeColorDark":    metadataThemeColorDark,
+		"readingTime":       metadataReadingTime,
+		"readingTimeSource": metadataReadingTimeSource,
+		"language":          ps.getDocumentLanguage(),
+		"authors":           shtml.UnescapeString(jsonLd["authors"]),
+		"tags":              strings.Join(getTags(jsonLd["keywords"], strings.Join(articleTags, ","), strings.Join(metaKeywords, ",")), "\n"),
+		"ogType":            values["og:type"],
+		"ogURL":             toAbsoluteURI(values["og:url"], ps.documentURI),
 	}
 }
 
@@ -1626,12 +2919,17 @@ func (ps *Parser) cleanStyles(node *html.Node) {
 
 	// Remove `style` and deprecated presentational attributes
 	for i := 0; i < len(presentationalAttributes); i++ {
-		dom.RemoveAttribute(node, presentationalAttributes[i])
+		if !ps.isKeptAttribute(presentationalAttributes[i]) {
+			dom.RemoveAttribute(node, presentationalAttributes[i])
+		}
 	}
 
 	if indexOf(deprecatedSizeAttributeElems, nodeTagName) != -1 {
-		dom.RemoveAttribute(node, "width")
-		dom.RemoveAttribute(node, "height")
+		for _, attrName := range []string{"width", "height"} {
+			if !ps.isKeptAttribute(attrName) {
+				dom.RemoveAttribute(node, attrName)
+			}
+		}
 	}
 
 	for child := dom.FirstElementChild(node); child != nil; child = dom.NextElementSibling(child) {
@@ -1706,21 +3004,7 @@ func (ps *Parser) clean(node *html.Node, tag string) {
 
 	ps.removeNodes(dom.GetElementsByTagName(node, tag), func(element *html.Node) bool {
 		// Allow youtube and vimeo videos through as people usually want to see those.
-		if isEmbed {
-			// First, check the elements attributes to see if any of them contain
-			// youtube or vimeo
-			for _, attr := range element.Attr {
-				if rxVideos.MatchString(attr.Val) {
-					return false
-				}
-			}
-
-			// For embed with <object> tag, check inner HTML as well.
-			if dom.TagName(element) == "object" && rxVideos.MatchString(dom.InnerHTML(element)) {
-				return false
-			}
-		}
-		return true
+		return !isEmbed || !ps.isAllowedEmbed(element)
 	})
 }
 
@@ -1847,9 +3131,16 @@ func (ps *Parser) markDataTables(root *html.Node) {
 func (ps *Parser) fixLazyImages(root *html.Node) {
 	imageNodes := ps.getAllNodesWithTag(root, "img", "picture", "figure")
 	ps.forEachNode(imageNodes, func(elem *html.Node, _ int) {
+		nodeTag := dom.TagName(elem)
+
+		// The attributes declared in LazyImageAttributes are trusted to
+		// have the real source, so check them first.
+		if nodeTag == "img" && ps.promoteLazyImage(elem) {
+			return
+		}
+
 		src := dom.GetAttribute(elem, "src")
 		srcset := dom.GetAttribute(elem, "srcset")
-		nodeTag := dom.TagName(elem)
 		nodeClass := dom.ClassName(elem)
 
 		// In some sites (e.g. Kotaku), they put 1px square image as base64 data uri in
@@ -1926,6 +3217,57 @@ func (ps *Parser) fixLazyImages(root *html.Node) {
 	})
 }
 
+// promoteLazyImage replaces the placeholder src of lazy-loaded img with
+// its real source, which is declared in one of the attributes in
+// LazyImageAttributes. The src is considered as placeholder when it's
+// empty, a data URI or a 1x1 spacer image. Returns true if the src is
+// replaced.
+func (ps *Parser) promoteLazyImage(img *html.Node) bool {
+	if len(ps.LazyImageAttributes) == 0 || !ps.isPlaceholderImage(img) {
+		return false
+	}
+
+	for _, attrName := range ps.LazyImageAttributes {
+		value := strings.TrimSpace(dom.GetAttribute(img, attrName))
+		if value == "" || strings.HasPrefix(strings.ToLower(value), "data:") {
+			continue
+		}
+
+		dom.SetAttribute(img, "src", value)
+
+		// The size of spacer doesn't belong to the real image.
+		if isSpacerSize(img) {
+			dom.RemoveAttribute(img, "width")
+			dom.RemoveAttribute(img, "height")
+		}
+		return true
+	}
+
+	return false
+}
+
+// isPlaceholderImage determines if the src of img is only a placeholder
+// of the real image.
+func (ps *Parser) isPlaceholderImage(img *html.Node) bool {
+	src := strings.TrimSpace(dom.GetAttribute(img, "src"))
+	switch {
+	case src == "",
+		strings.HasPrefix(strings.ToLower(src), "data:"),
+		rxSpacerImage.MatchString(src),
+		isSpacerSize(img):
+		return true
+	default:
+		return false
+	}
+}
+
+// isSpacerSize determines if img is declared as 1x1 image.
+func isSpacerSize(img *html.Node) bool {
+	width, errWidth := strconv.Atoi(strings.TrimSpace(dom.GetAttribute(img, "width")))
+	height, errHeight := strconv.Atoi(strings.TrimSpace(dom.GetAttribute(img, "height")))
+	return errWidth == nil && errHeight == nil && width <= 1 && height <= 1
+}
+
 // cleanConditionally cleans an element of all tags of type "tag" if
 // they look fishy. "Fishy" is an algorithm based on content length,
 // classnames, link density, number of images & embeds, etc.
@@ -1934,6 +3276,8 @@ func (ps *Parser) cleanConditionally(element *html.Node, tag string) {
 		return
 	}
 
+	thresholds := ps.getConditionalCleanThresholds()
+
 	// Gather counts for other typical elements embedded within.
 	// Traverse backwards so we can remove nodes at the same time
 	// without effecting the traversal.
@@ -1947,7 +3291,7 @@ func (ps *Parser) cleanConditionally(element *html.Node, tag string) {
 		isList := tag == "ul" || tag == "ol"
 		if !isList {
 			var listLength int
-			listNodes := ps.getAllNodesWithTag(node, "ul", "ol")
+			listNodes := ps.getAllNodesWithTag(node, "ul", "ol", "dl")
 			ps.forEachNode(listNodes, func(list *html.Node, _ int) {
 				listLength += charCount(ps.getInnerText(list, true))
 			})
@@ -1965,35 +3309,33 @@ func (ps *Parser) cleanConditionally(element *html.Node, tag string) {
 			return false
 		}
 
+		// Code blocks are kept verbatim, including the wrapper which
+		// mostly contains them.
+		if ps.hasAncestorTag(node, "pre", -1, nil) || ps.isCodeBlockWrapper(node) {
+			return false
+		}
+
 		var contentScore int
 		weight := ps.getClassWeight(node)
 		if weight+contentScore < 0 {
 			return true
 		}
 
-		if ps.getCharCount(node, ",") < 10 {
+		if ps.getCharCount(node, ",") < thresholds.MinCommas {
 			// If there are not very many commas, and the number of
 			// non-paragraph elements is more than paragraphs or other
 			// ominous signs, remove the element.
 			p := float64(len(dom.GetElementsByTagName(node, "p")))
 			img := float64(len(dom.GetElementsByTagName(node, "img")))
-			li := float64(len(dom.GetElementsByTagName(node, "li")) - 100)
+			li := float64(len(dom.GetElementsByTagName(node, "li")) - thresholds.ListItemAllowance)
 			input := float64(len(dom.GetElementsByTagName(node, "input")))
 
 			embedCount := 0
 			embeds := ps.getAllNodesWithTag(node, "object", "embed", "iframe")
 
 			for _, embed := range embeds {
-				// If this embed has attribute that matches video regex,
-				// don't delete it.
-				for _, attr := range embed.Attr {
-					if rxVideos.MatchString(attr.Val) {
-						return false
-					}
-				}
-
-				// For embed with <object> tag, check inner HTML as well.
-				if dom.TagName(embed) == "object" && rxVideos.MatchString(dom.InnerHTML(embed)) {
+				// If this embed is video player, don't delete it.
+				if ps.isAllowedEmbed(embed) {
 					return false
 				}
 
@@ -2003,13 +3345,13 @@ func (ps *Parser) cleanConditionally(element *html.Node, tag string) {
 			linkDensity := ps.getLinkDensity(node)
 			contentLength := charCount(ps.getInnerText(node, true))
 
-			return (img > 1 && p/img < 0.5 && !ps.hasAncestorTag(node, "figure", 3, nil)) ||
+			return (img > 1 && p/img < thresholds.MinParagraphsPerImage && !ps.hasAncestorTag(node, "figure", 3, nil)) ||
 				(!isList && li > p) ||
 				(input > math.Floor(p/3)) ||
-				(!isList && contentLength < 25 && (img == 0 || img > 2) && !ps.hasAncestorTag(node, "figure", 3, nil)) ||
-				(!isList && weight < 25 && linkDensity > 0.2) ||
-				(weight >= 25 && linkDensity > 0.5) ||
-				((embedCount == 1 && contentLength < 75) || embedCount > 1)
+				(!isList && contentLength < thresholds.MinContentLength && (img == 0 || img > float64(thresholds.MaxImages)) && !ps.hasAncestorTag(node, "figure", 3, nil)) ||
+				(!isList && weight < thresholds.HighClassWeight && linkDensity > thresholds.MaxLinkDensity) ||
+				(weight >= thresholds.HighClassWeight && linkDensity > thresholds.MaxLinkDensityHighWeight) ||
+				((embedCount == 1 && contentLength < thresholds.MinEmbedContentLength) || embedCount > thresholds.MaxEmbeds)
 		}
 
 		return false
@@ -2063,8 +3405,12 @@ func (ps *Parser) isProbablyVisible(node *html.Node) bool {
 // =========================================================
 
 // getArticleFavicon attempts to get high quality favicon
-// that used in article. It will only pick favicon in PNG
-// format, so small favicon that uses ico file won't be picked.
+// that used in article. Every icon declared in <link> is
+// considered (e.g. rel="icon", "shortcut icon", "apple-touch-icon"
+// and "mask-icon"), and the largest square one is picked. The size
+// is taken from its sizes attribute or URL, while apple-touch-icon
+// without size is assumed to be 180x180 as used by iOS. If there
+// are no icons declared, /favicon.ico in site root is used.
 // Using algorithm by philippe_b.
 func (ps *Parser) getArticleFavicon() string {
 	favicon := ""
@@ -2072,8 +3418,7 @@ func (ps *Parser) getArticleFavicon() string {
 	linkElements := dom.GetElementsByTagName(ps.doc, "link")
 
 	ps.forEachNode(linkElements, func(link *html.Node, _ int) {
-		linkRel := strings.TrimSpace(dom.GetAttribute(link, "rel"))
-		linkType := strings.TrimSpace(dom.GetAttribute(link, "type"))
+		linkRel := strings.ToLower(strings.TrimSpace(dom.GetAttribute(link, "rel")))
 		linkHref := strings.TrimSpace(dom.GetAttribute(link, "href"))
 		linkSizes := strings.TrimSpace(dom.GetAttribute(link, "sizes"))
 
@@ -2081,19 +3426,13 @@ func (ps *Parser) getArticleFavicon() string {
 			return
 		}
 
-		if linkType != "image/png" && !strings.Contains(linkHref, ".png") {
+		size, isSquare := getFaviconSize(linkSizes, linkHref)
+		if !isSquare {
 			return
 		}
 
-		size := 0
-		for _, sizesLocation := range []string{linkSizes, linkHref} {
-			sizeParts := rxFaviconSize.FindStringSubmatch(sizesLocation)
-			if len(sizeParts) != 3 || sizeParts[1] != sizeParts[2] {
-				continue
-			}
-
-			size, _ = strconv.Atoi(sizeParts[1])
-			break
+		if size == 0 && strings.Contains(linkRel, "apple-touch-icon") {
+			size = 180
 		}
 
 		if size > faviconSize {
@@ -2102,9 +3441,126 @@ func (ps *Parser) getArticleFavicon() string {
 		}
 	})
 
+	if favicon == "" {
+		if ps.documentURI == nil {
+			return ""
+		}
+		favicon = "/favicon.ico"
+	}
+
 	return toAbsoluteURI(favicon, ps.documentURI)
 }
 
+// getFaviconSize returns the largest size declared in the sizes of
+// favicon, e.g. "16x16 32x32", or in its URL. Returns zero if the size
+// is unknown, and false if the icon is not square.
+func getFaviconSize(sizes string, href string) (int, bool) {
+	for _, sizesLocation := range []string{sizes, href} {
+		size := 0
+		isSquare := false
+		for _, sizeParts := range rxFaviconSize.FindAllStringSubmatch(sizesLocation, -1) {
+			if sizeParts[1] != sizeParts[2] {
+				continue
+			}
+
+			isSquare = true
+			if width, _ := strconv.Atoi(sizeParts[1]); width > size {
+				size = width
+			}
+		}
+
+		if isSquare {
+			return size, true
+		}
+
+		// Only the declared sizes can tell that icon is not square.
+		if sizesLocation == sizes && rxFaviconSize.MatchString(sizes) {
+			return 0, false
+		}
+	}
+
+	return 0, true
+}
+
+// getArticleAMPURL returns the URL of AMP version of the page, which
+// declared in <link rel="amphtml">.
+func (ps *Parser) getArticleAMPURL() string {
+	return toAbsoluteURI(ps.getLinkHref("amphtml"), ps.documentURI)
+}
+
+// getArticleCanonicalURL returns the canonical URL of the page, which
+// declared in <link rel="canonical">, or in og:url meta as fallback.
+// Unlike the page URL, it doesn't contain the tracking params or the
+// path of syndicated copy, so it's useful for deduplication.
+func (ps *Parser) getArticleCanonicalURL(ogURL string) string {
+	canonicalURL := strOr(ps.getLinkHref("canonical"), strings.TrimSpace(ogURL))
+	return toAbsoluteURI(canonicalURL, ps.documentURI)
+}
+
+// getLinkHref returns the href of the first <link> whose rel contains
+// the specified type.
+func (ps *Parser) getLinkHref(rel string) string {
+	var href string
+	linkElements := dom.GetElementsByTagName(ps.doc, "link")
+	ps.someNode(linkElements, func(link *html.Node) bool {
+		linkRels := strings.Fields(strings.ToLower(dom.GetAttribute(link, "rel")))
+		if indexOf(linkRels, rel) == -1 {
+			return false
+		}
+
+		href = strings.TrimSpace(dom.GetAttribute(link, "href"))
+		return href != ""
+	})
+
+	return href
+}
+
+// isAMPPage checks if the page is an AMP page, i.e. its <html> has amp
+// or ⚡ attribute, or it contains AMP components like <amp-img>.
+func (ps *Parser) isAMPPage() bool {
+	htmlElements := dom.GetElementsByTagName(ps.doc, "html")
+	if len(htmlElements) > 0 && (dom.HasAttribute(htmlElements[0], "amp") || dom.HasAttribute(htmlElements[0], "⚡")) {
+		return true
+	}
+
+	return ps.someNode(dom.GetElementsByTagName(ps.doc, "*"), func(node *html.Node) bool {
+		return strings.HasPrefix(dom.TagName(node), "amp-")
+	})
+}
+
+// hasVisibleText checks whether node contains at least minLength chars
+// of visible text. Scripts, styles and hidden elements are ignored.
+func (ps *Parser) hasVisibleText(node *html.Node, minLength int) bool {
+	textLength := 0
+	var walker func(*html.Node) bool
+
+	walker = func(node *html.Node) bool {
+		switch node.Type {
+		case html.TextNode:
+			textLength += charCount(strings.TrimSpace(node.Data))
+			return textLength >= minLength
+		case html.ElementNode:
+			switch dom.TagName(node) {
+			case "head", "script", "noscript", "style", "template":
+				return false
+			}
+
+			if !ps.isProbablyVisible(node) {
+				return false
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if walker(child) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return walker(node)
+}
+
 // removeComments find all comments in document then remove it.
 func (ps *Parser) removeComments(doc *html.Node) {
 	// Find all comments
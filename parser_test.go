@@ -1,19 +1,36 @@
 package readability
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
 	fp "path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-shiori/dom"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"golang.org/x/net/html"
 )
 
+// testSentence is the filler text used to build test pages.
+const testSentence = "Lorem ipsum dolor sit amet, consectetur adipiscing elit. "
+
+// testParagraph is a paragraph that long enough to be scored as
+// article content.
+var testParagraph = "<p>" + strings.Repeat(testSentence, 12) + "</p>"
+
+// articleHTML returns a test page with head as content of <head>, and
+// body followed by two filler paragraphs as content of <article>.
+func articleHTML(head, body string) string {
+	return `<html><head>` + head + `</head><body><article>` +
+		body + testParagraph + testParagraph + `</article></body></html>`
+}
+
 func getNodeExcerpt(node *html.Node) string {
 	outer := dom.OuterHTML(node)
 	outer = strings.Join(strings.Fields(outer), " ")
@@ -23,6 +40,34 @@ func getNodeExcerpt(node *html.Node) string {
 	return outer[:120]
 }
 
+// parseHTMLString parses raw HTML using the specified parser, as if
+// it's fetched from http://fakehost/test/page.html.
+func parseHTMLString(t *testing.T, parser Parser, rawHTML string) Article {
+	parsedURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	article, err := parser.Parse(strings.NewReader(rawHTML), parsedURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse HTML: %v", err)
+	}
+	return article
+}
+
+// parseTestPage parses source.html of the named page in test-pages
+// using the specified parser.
+func parseTestPage(t *testing.T, parser Parser, name string) Article {
+	f, err := os.Open(fp.Join("test-pages", name, "source.html"))
+	if err != nil {
+		t.Fatalf("\nfailed to open test file: %v", err)
+	}
+	defer f.Close()
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	article, err := parser.Parse(f, pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse test file: %v", err)
+	}
+	return article
+}
+
 func compareArticleContent(result, expected *html.Node) error {
 	// Make sure number of nodes is same
 	resultNodesCount := len(dom.Children(result))
@@ -159,10 +204,1251 @@ func Test_parser(t *testing.T) {
 			if err != nil {
 				t1.Errorf("\n%v", err)
 			}
+		})
+	}
+}
+
+func Test_CleanContent(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	contentHTML := `<p class="lead">Hello <a href="/about">world</a></p>` +
+		`<script>alert("hi")</script><button>Subscribe</button>` +
+		`<img src="images/photo.jpg">`
+
+	result, err := CleanContent(contentHTML, pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to clean content: %v", err)
+	}
+
+	for _, unwanted := range []string{"<script", "<button", `class="lead"`} {
+		if strings.Contains(result, unwanted) {
+			t.Errorf("\nwant %s removed, got %s", unwanted, result)
+		}
+	}
+
+	for _, wanted := range []string{`href="http://fakehost/about"`, `src="http://fakehost/test/images/photo.jpg"`} {
+		if !strings.Contains(result, wanted) {
+			t.Errorf("\nwant %s, got %s", wanted, result)
+		}
+	}
+}
+
+func Test_ariaAttributesPreserved(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<figure aria-label="Chart of monthly sales" role="group"><img src="chart.png" alt="chart"></figure>` +
+		testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	for _, attr := range []string{`aria-label="Chart of monthly sales"`, `role="group"`} {
+		if !strings.Contains(article.Content, attr) {
+			t.Errorf("\nwant %s to survive, got %s", attr, article.Content)
+		}
+	}
+}
+
+func Test_countMedia(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p><img src="a.png"><img src="b.png"></p>` + testParagraph +
+		`<p><iframe src="https://www.youtube.com/embed/xyz"></iframe></p>` + testParagraph +
+		`<video src="clip.mp4"></video></article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if article.ImageCount != 2 || article.VideoCount != 2 {
+		t.Errorf("\n"+
+			"want : 2 images, 2 videos\n"+
+			"got  : %d images, %d videos", article.ImageCount, article.VideoCount)
+	}
+}
+
+func Test_getHeaderImage(t *testing.T) {
+	scenarios := map[string]string{
+		`<figure><img src="/hero.jpg"><figcaption>Hero</figcaption></figure>`:      "http://fakehost/hero.jpg",
+		`<p><img src="/icon.png" width="16"> <img src="hero.jpg" width="800"></p>`: "http://fakehost/test/hero.jpg",
+		`<p>Some text first <img src="/inline.jpg"></p>`:                           "",
+	}
+
+	for opening, expected := range scenarios {
+		rawHTML := articleHTML("", opening)
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.HeaderImage != expected || article.StartsWithImage != (expected != "") {
+			t.Errorf("\n"+
+				"html : %s\n"+
+				"want : %q\n"+
+				"got  : %q (%v)", opening, expected, article.HeaderImage, article.StartsWithImage)
+		}
+	}
+}
+
+func Test_normalizeTypography(t *testing.T) {
+	rawHTML := `<html><head><title>“Quoted” title — with dash</title>` +
+		`<meta name="description" content="It’s 1–2 days…"></head><body><article>` +
+		`<p>She said “it’s fine” — then left…</p>` + testParagraph +
+		`<pre><code>s := “keep—this”</code></pre>` + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.NormalizeTypography = true
+	parser.EmDashReplacement = "-"
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if article.Title != `"Quoted" title - with dash` {
+		t.Errorf("\nwant normalized title, got %q", article.Title)
+	}
+
+	if article.Excerpt != "It's 1-2 days..." {
+		t.Errorf("\nwant normalized excerpt, got %q", article.Excerpt)
+	}
+
+	if !strings.Contains(article.TextContent, `She said "it's fine" - then left...`) {
+		t.Errorf("\nwant normalized text, got %q", article.TextContent)
+	}
+
+	if !strings.Contains(article.TextContent, "s := “keep—this”") {
+		t.Errorf("\nwant code untouched, got %q", article.TextContent)
+	}
+}
+
+func Test_candidateTags(t *testing.T) {
+	doc, _ := dom.Parse(strings.NewReader(`<html><body><div></div><section></section><article></article></body></html>`))
+	div := dom.GetElementsByTagName(doc, "div")[0]
+	section := dom.GetElementsByTagName(doc, "section")[0]
+	article := dom.GetElementsByTagName(doc, "article")[0]
+
+	scenarios := []struct {
+		candidateTags []string
+		expected      []float64
+	}{
+		{nil, []float64{5, 0, 0}},
+		{[]string{"div"}, []float64{5, 0, 0}},
+		{[]string{"div", "section", "article"}, []float64{5, 5, 5}},
+	}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.CandidateTags = scenario.candidateTags
+		for i, node := range []*html.Node{div, section, article} {
+			parser.initializeNode(node)
+			if score := parser.getContentScore(node); score != scenario.expected[i] {
+				t.Errorf("\n"+
+					"tags : %v\n"+
+					"node : %s\n"+
+					"want : %v\n"+
+					"got  : %v", scenario.candidateTags, dom.TagName(node), scenario.expected[i], score)
+			}
+		}
+	}
+}
+
+func Test_tagWeights(t *testing.T) {
+	doc, _ := dom.Parse(strings.NewReader(`<html><body><div></div><section></section><pre></pre></body></html>`))
+	div := dom.GetElementsByTagName(doc, "div")[0]
+	section := dom.GetElementsByTagName(doc, "section")[0]
+	pre := dom.GetElementsByTagName(doc, "pre")[0]
+
+	parser := NewParser()
+	parser.TagWeights = map[string]int{"section": 10, "div": 0}
+	for i, node := range []*html.Node{div, section, pre} {
+		parser.initializeNode(node)
+		if expected := []float64{0, 10, 3}[i]; parser.getContentScore(node) != expected {
+			t.Errorf("\n%s: want score %v, got %v", dom.TagName(node), expected, parser.getContentScore(node))
+		}
+	}
+
+	// Without the boost, the teasers beside the section are extracted
+	// as well, since <div> is given head start.
+	story := strings.Repeat("<p>"+strings.Repeat("The main story continues with more details here. ", 6)+"</p>", 2)
+	teasers := strings.Repeat("<p>Read more of our related teasers</p>", 4)
+	rawHTML := `<html><body><section>` + story + `</section><div class="teasers">` + teasers + `</div></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if !strings.Contains(article.TextContent, "related teasers") {
+		t.Errorf("\nwant teasers extracted with default weights, got %q", article.TextContent)
+	}
+
+	parser = NewParser()
+	parser.TagWeights = map[string]int{"section": 10}
+	article = parseHTMLString(t, parser, rawHTML)
+	if !strings.Contains(article.TextContent, "main story") || strings.Contains(article.TextContent, "related teasers") {
+		t.Errorf("\nwant only the section extracted, got %q", article.TextContent)
+	}
+}
+
+func Test_themeColor(t *testing.T) {
+	scenarios := map[string][2]string{
+		`<meta name="theme-color" content="#4285F4">`: {"#4285f4", ""},
+		`<meta name="theme-color" media="(prefers-color-scheme: dark)" content="#000">` +
+			`<meta name="theme-color" media="(prefers-color-scheme: light)" content="white">`: {"#ffffff", "#000000"},
+		``: {"", ""},
+	}
+
+	for head, expected := range scenarios {
+		rawHTML := articleHTML(head, "")
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.ThemeColor != expected[0] || article.ThemeColorDark != expected[1] {
+			t.Errorf("\n"+
+				"head : %s\n"+
+				"want : %q, %q\n"+
+				"got  : %q, %q", head, expected[0], expected[1], article.ThemeColor, article.ThemeColorDark)
+		}
+	}
+}
+
+func Test_preserveRootTag(t *testing.T) {
+	rawHTML := `<html><body><nav><a href="/">Home</a></nav>` +
+		`<article class="post">` + testParagraph + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	if !strings.HasPrefix(article.Content, `<div id="readability-page-1" class="page"><article>`) {
+		t.Errorf("\nwant article wrapped in div by default, got %s", article.Content)
+	}
+
+	parser.PreserveRootTag = true
+	article = parseHTMLString(t, parser, rawHTML)
+	if !strings.HasPrefix(article.Content, `<article id="readability-page-1" class="page"><p>`) ||
+		!strings.HasSuffix(article.Content, `</p></article>`) {
+		t.Errorf("\nwant article as root, got %s", article.Content)
+	}
+
+	if article.Node == nil || dom.TagName(article.Node) != "article" {
+		t.Errorf("\nwant article as readable node, got %v", article.Node)
+	}
+}
+
+func Test_ampDetection(t *testing.T) {
+	scenarios := []struct {
+		rawHTML string
+		isAMP   bool
+		ampURL  string
+	}{
+		{`<html amp><head><link rel="canonical" href="/article"></head><body><article>` + testParagraph + testParagraph + `</article></body></html>`, true, ""},
+		{`<html ⚡><body><article>` + testParagraph + testParagraph + `</article></body></html>`, true, ""},
+		{articleHTML("", `<amp-img src="a.jpg" width="800" height="600"></amp-img>`), true, ""},
+		{articleHTML(`<link rel="amphtml" href="/amp/article">`, ""), false, "http://fakehost/amp/article"},
+	}
+
+	for _, scenario := range scenarios {
+		article := parseHTMLString(t, NewParser(), scenario.rawHTML)
+		if article.IsAMP != scenario.isAMP || article.AMPURL != scenario.ampURL {
+			t.Errorf("\n"+
+				"html : %.80s\n"+
+				"want : %v, %q\n"+
+				"got  : %v, %q", scenario.rawHTML, scenario.isAMP, scenario.ampURL, article.IsAMP, article.AMPURL)
+		}
+	}
+}
+
+func Test_collectTimings(t *testing.T) {
+	rawHTML := articleHTML("", "")
+
+	parser := NewParser()
+	if article := parseHTMLString(t, parser, rawHTML); article.Timings != nil {
+		t.Errorf("\nwant no timings by default, got %v", article.Timings)
+	}
+
+	parser.CollectTimings = true
+	article := parseHTMLString(t, parser, rawHTML)
+	for _, phase := range []string{"parse", "getJSONLD", "prepDocument", "grabArticle", "postProcessContent", "parseDocument"} {
+		if _, exist := article.Timings[phase]; !exist {
+			t.Errorf("\nwant timing of %s, got %v", phase, article.Timings)
+		}
+	}
+}
+
+func Test_limitSiblings(t *testing.T) {
+	siblings := make([]*html.Node, 10)
+	for i := range siblings {
+		siblings[i] = dom.CreateElement("div")
+	}
+
+	scenarios := []struct {
+		maxCandidates int
+		topIdx        int
+		start, end    int
+	}{
+		{0, 5, 0, 10},
+		{20, 5, 0, 10},
+		{4, 5, 3, 7},
+		{4, 0, 0, 4},
+		{4, 9, 6, 10},
+	}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.MaxCandidates = scenario.maxCandidates
+		result := parser.limitSiblings(siblings, siblings[scenario.topIdx])
+		expected := siblings[scenario.start:scenario.end]
+
+		if len(result) != len(expected) || result[0] != expected[0] {
+			t.Errorf("\nmax %d, top %d: want siblings[%d:%d], got %d siblings",
+				scenario.maxCandidates, scenario.topIdx, scenario.start, scenario.end, len(result))
+		}
+	}
+}
+
+func Benchmark_flatDOM(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString("<html><body><div class=\"comments\">")
+	for i := 0; i < 10000; i++ {
+		sb.WriteString("<div class=\"comment\"><p>Comment number, with some words, and a few commas in it.</p></div>")
+	}
+	sb.WriteString("</div></body></html>")
+
+	doc, err := dom.Parse(strings.NewReader(sb.String()))
+	if err != nil {
+		b.Fatalf("failed to parse HTML: %v", err)
+	}
 
-			if resultArticle.ModifiedTime != nil {
-				t1.Fatalf("Modified time is %s", resultArticle.ModifiedTime)
+	for _, maxCandidates := range []int{0, 100} {
+		b.Run(fmt.Sprintf("MaxCandidates=%d", maxCandidates), func(b *testing.B) {
+			parser := NewParser()
+			parser.MaxCandidates = maxCandidates
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.ParseDocument(doc, nil); err != nil {
+					b.Fatalf("failed to parse document: %v", err)
+				}
 			}
 		})
 	}
 }
+
+func Test_filterTags(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<table><tr><td>cell one</td><td>cell two</td></tr></table>` +
+		`<p>Some <em>emphasized</em> words</p>` + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.AllowedTags = []string{"p", "a", "div"}
+	parser.BlockedTags = []string{"TABLE"}
+	article := parseHTMLString(t, parser, rawHTML)
+
+	for _, unwanted := range []string{"<table", "cell one", "<em>"} {
+		if strings.Contains(article.Content, unwanted) {
+			t.Errorf("\nwant %s removed, got %s", unwanted, article.Content)
+		}
+	}
+
+	if !strings.Contains(article.Content, "<p>Some emphasized words</p>") {
+		t.Errorf("\nwant <em> unwrapped, got %s", article.Content)
+	}
+}
+
+func Test_annotateOutput(t *testing.T) {
+	rawHTML := `<html><body><article class="post-body">` + testParagraph +
+		`<figure class="wp-block-image"><img src="/a.jpg"><figcaption>A caption</figcaption></figure>` +
+		`<blockquote class="pull-quote big"><p>A pulled quote from the article.</p></blockquote>` +
+		`<blockquote><p>A regular quote.</p></blockquote>` +
+		testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	if strings.Contains(article.Content, "rdbl-") {
+		t.Errorf("\nwant no semantic classes by default, got %s", article.Content)
+	}
+
+	parser.AnnotateOutput = true
+	article = parseHTMLString(t, parser, rawHTML)
+	for _, expected := range []string{
+		`<p class="rdbl-lead">`,
+		`<figure class="rdbl-figure">`,
+		`<figcaption class="rdbl-caption">`,
+		`<blockquote class="rdbl-pullquote">`,
+		`<blockquote class="rdbl-quote">`,
+	} {
+		if !strings.Contains(article.Content, expected) {
+			t.Errorf("\nwant %s, got %s", expected, article.Content)
+		}
+	}
+
+	for _, unwanted := range []string{"wp-block-image", "pull-quote", "post-body"} {
+		if strings.Contains(article.Content, unwanted) {
+			t.Errorf("\nwant class %s removed, got %s", unwanted, article.Content)
+		}
+	}
+}
+
+func Test_cleanIDs(t *testing.T) {
+	rawHTML := `<html><body><article>` +
+		`<p>Jump to <a href="#step-3">step 3</a> or <a href="http://fakehost/test/page.html#step-4">step 4</a>.</p>` +
+		testParagraph + `<h2 id="step-3">Step 3</h2>` + testParagraph +
+		`<h2 id="step-4">Step 4</h2>` + testParagraph +
+		`<h2 id="unreferenced">Step 5</h2>` + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.CleanIDs = true
+	article := parseHTMLString(t, parser, rawHTML)
+
+	for _, expected := range []string{`id="step-3"`, `id="step-4"`, `id="readability-page-1"`} {
+		if !strings.Contains(article.Content, expected) {
+			t.Errorf("\nwant %s preserved, got %s", expected, article.Content)
+		}
+	}
+
+	if strings.Contains(article.Content, `id="unreferenced"`) {
+		t.Errorf("\nwant unreferenced id removed, got %s", article.Content)
+	}
+}
+
+func Test_unsafeURLs(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p>Click <a href="javascript:alert(1)">here</a> or <a href="VBScript:msgbox(1)">there</a>.</p>` +
+		`<p><img src="data:text/html,&lt;script&gt;alert(1)&lt;/script&gt;" alt="bad">` +
+		`<img src="data:image/gif;base64,R0lGODlhAQABAAAAACw=" alt="good"></p>` +
+		`<iframe src="javascript:alert(1)"></iframe>` +
+		testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	for _, unwanted := range []string{"javascript:", "VBScript:", "data:text/html", "<iframe"} {
+		if strings.Contains(article.Content, unwanted) {
+			t.Errorf("\nwant %s removed, got %s", unwanted, article.Content)
+		}
+	}
+
+	for _, expected := range []string{"Click here or there.", "data:image/gif", `alt="bad"`} {
+		if !strings.Contains(article.Content, expected) {
+			t.Errorf("\nwant %s kept, got %s", expected, article.Content)
+		}
+	}
+}
+
+func Test_unsafeURLAttributes(t *testing.T) {
+	doc, _ := dom.Parse(strings.NewReader(`<html><body>` +
+		`<img src="data:image/svg+xml,&lt;svg onload=alert(1)&gt;" alt="vector">` +
+		`<img src="data:image/png;base64,iVBORw0KGgo=" alt="raster">` +
+		`<iframe src="data:image/svg+xml,&lt;svg onload=alert(1)&gt;"></iframe>` +
+		`<embed src="data:image/png;base64,iVBORw0KGgo=">` +
+		`<object data="data:text/html,&lt;script&gt;alert(1)&lt;/script&gt;"></object>` +
+		`<iframe src="https://www.youtube.com/embed/abc"></iframe>` +
+		`<map><area href="javascript:alert(1)" alt="bad"><area href="/page.html" alt="good"></map>` +
+		`<form action="javascript:alert(1)"><button formaction="vbscript:msgbox(1)">Go</button></form>` +
+		`<form action="/search"><input type="submit" formaction="search.php"></form>` +
+		`</body></html>`))
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	parser := NewParser()
+	parser.withParseState(context.Background(), doc, pageURL).fixRelativeURIs(doc)
+
+	var buf strings.Builder
+	html.Render(&buf, doc)
+	content := buf.String()
+
+	for _, unwanted := range []string{"svg", "<embed", "<object", "javascript:", "vbscript:"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("\nwant %s removed, got %s", unwanted, content)
+		}
+	}
+
+	for _, expected := range []string{
+		`src="data:image/png;base64,iVBORw0KGgo="`,
+		`src="https://www.youtube.com/embed/abc"`,
+		`href="http://fakehost/page.html"`,
+		`action="http://fakehost/search"`,
+		`formaction="http://fakehost/test/search.php"`,
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("\nwant %s, got %s", expected, content)
+		}
+	}
+}
+
+func Test_getQuotes(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<blockquote cite="/speeches/1961">` +
+		`<p>Ask not what your country can do for you.</p>` +
+		`<footer>— <cite>John F. Kennedy</cite></footer></blockquote>` +
+		testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if !strings.Contains(article.Content, `cite="http://fakehost/speeches/1961"`) ||
+		!strings.Contains(article.Content, "<cite>John F. Kennedy</cite>") {
+		t.Errorf("\nwant cited blockquote preserved, got %s", article.Content)
+	}
+
+	expected := QuoteInfo{
+		Text:      "Ask not what your country can do for you.",
+		Source:    "John F. Kennedy",
+		SourceURL: "http://fakehost/speeches/1961",
+	}
+
+	if len(article.Quotes) != 1 || article.Quotes[0] != expected {
+		t.Errorf("\n"+
+			"want : %+v\n"+
+			"got  : %+v", expected, article.Quotes)
+	}
+}
+
+func Test_getAnnotations(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p>The city of <span data-entity-type="place" data-wikidata="Q84">London</span> is large.</p>` +
+		testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.ExtractDataAnnotations = []string{"data-entity-type", "data-wikidata"}
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if len(article.Annotations) != 1 {
+		t.Fatalf("\nwant 1 annotation, got %d", len(article.Annotations))
+	}
+
+	annotation := article.Annotations[0]
+	if annotation.Text != "London" ||
+		annotation.Attributes["data-wikidata"] != "Q84" ||
+		annotation.Attributes["data-entity-type"] != "place" {
+		t.Errorf("\nunexpected annotation %+v", annotation)
+	}
+
+	textAtOffset := article.TextContent[annotation.Offset : annotation.Offset+len(annotation.Text)]
+	if textAtOffset != annotation.Text {
+		t.Errorf("\n"+
+			"want text at offset : %q\n"+
+			"got                 : %q", annotation.Text, textAtOffset)
+	}
+}
+
+func Test_getAnnotationsMojibake(t *testing.T) {
+	rawHTML := `<html><body><article><p>FranÃ§ois et AndrÃ© sont partis.</p>` + testParagraph +
+		`<p>Le cafÃ© de <span data-wikidata="Q90">PÃ¢ris</span> est grand.</p>` +
+		testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.RepairMojibake = true
+	parser.ExtractDataAnnotations = []string{"data-wikidata"}
+	article := parseHTMLString(t, parser, rawHTML)
+
+	if len(article.Annotations) != 1 {
+		t.Fatalf("\nwant 1 annotation, got %d", len(article.Annotations))
+	}
+
+	annotation := article.Annotations[0]
+	if annotation.Text != "Pâris" {
+		t.Errorf("\nwant repaired annotation text %q, got %q", "Pâris", annotation.Text)
+	}
+
+	textAtOffset := article.TextContent[annotation.Offset : annotation.Offset+len(annotation.Text)]
+	if textAtOffset != annotation.Text {
+		t.Errorf("\n"+
+			"want text at offset : %q\n"+
+			"got                 : %q", annotation.Text, textAtOffset)
+	}
+}
+
+func Test_minVisibleTextLength(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	emptyPage := `<html><head><title>Login</title><script>var x = "` +
+		strings.Repeat("not visible ", 20) + `";</script></head>` +
+		`<body><div style="display:none">` + strings.Repeat("hidden ", 20) + `</div>` +
+		`<p>Please log in</p></body></html>`
+
+	parser := NewParser()
+	parser.MinVisibleTextLength = 25
+	if _, err := parser.Parse(strings.NewReader(emptyPage), pageURL); err != ErrNoContent {
+		t.Errorf("\nwant ErrNoContent, got %v", err)
+	}
+
+	parser.MinVisibleTextLength = 0
+	if _, err := parser.Parse(strings.NewReader(emptyPage), pageURL); err != nil {
+		t.Errorf("\nwant no error when check disabled, got %v", err)
+	}
+}
+
+func Test_authorImage(t *testing.T) {
+	scenarios := map[string]string{
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",
+			"author":{"@type":"Person","name":"Jane Doe","image":{"@type":"ImageObject","url":"/img/jane.jpg"}}}</script>`: "http://fakehost/img/jane.jpg",
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",
+			"author":[{"@type":"Person","name":"Jane Doe","image":"https://cdn.example.com/jane.jpg"}]}</script>`: "https://cdn.example.com/jane.jpg",
+		`<div class="byline"><img src="avatars/john.png"> By John Smith</div>`: "http://fakehost/test/avatars/john.png",
+		``: "",
+	}
+
+	for extraHTML, expected := range scenarios {
+		rawHTML := `<html><body>` + extraHTML + `<article>` + testParagraph + testParagraph + `</article></body></html>`
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.AuthorImage != expected {
+			t.Errorf("\n"+
+				"html : %s\n"+
+				"want : %q\n"+
+				"got  : %q", extraHTML, expected, article.AuthorImage)
+		}
+	}
+}
+
+func Test_dropAriaHidden(t *testing.T) {
+	rawHTML := `<html><body><article>` +
+		`<p><span class="material-icons" aria-hidden="true">arrow_forward</span>Read the full story below.</p>` +
+		testParagraph + testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if strings.Contains(article.TextContent, "arrow_forward") {
+		t.Errorf("\nwant decorative icon to be removed, got %.80q", article.TextContent)
+	}
+
+	if !strings.Contains(article.TextContent, "Read the full story below.") {
+		t.Errorf("\nwant text next to icon to be kept, got %.80q", article.TextContent)
+	}
+
+	// Hidden wrapper with visible content is kept.
+	doc, _ := dom.Parse(strings.NewReader(`<html><body>` +
+		`<div aria-hidden="true">` + testParagraph + `</div>` +
+		`<span aria-hidden="true">|</span></body></html>`))
+
+	parser := NewParser()
+	parser.removeAriaHidden(doc)
+	if len(dom.GetElementsByTagName(doc, "div")) != 1 || len(dom.GetElementsByTagName(doc, "span")) != 0 {
+		t.Errorf("\nwant only the decorative separator removed, got %s", dom.OuterHTML(doc))
+	}
+}
+
+func Test_articleDates(t *testing.T) {
+	scenarios := map[string]string{
+		"json-ld": `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle",` +
+			`"datePublished":"2021-03-04T10:00:00Z","dateModified":"2021-03-05T12:30:00Z"}</script>`,
+		"meta": `<meta property="article:published_time" content="2021-03-04T10:00:00Z">` +
+			`<meta property="article:modified_time" content="2021-03-05T12:30:00Z">`,
+	}
+
+	for name, head := range scenarios {
+		rawHTML := articleHTML(head, "")
+
+		// Make sure the keys read by ParseDocument are the ones written
+		// by getArticleMetadata.
+		doc, _ := dom.Parse(strings.NewReader(rawHTML))
+		parser := NewParser()
+		parser.doc = doc
+		jsonLd, _ := parser.getJSONLD()
+		metadata := parser.getArticleMetadata(jsonLd)
+		for _, key := range []string{"datePublished", "dateModified"} {
+			if metadata[key] == "" {
+				t.Errorf("\n%s: want metadata %q, got %v", name, key, metadata)
+			}
+		}
+
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.PublishedTime == nil || article.PublishedTime.Format(time.RFC3339) != "2021-03-04T10:00:00Z" {
+			t.Errorf("\n%s: want published time 2021-03-04T10:00:00Z, got %v", name, article.PublishedTime)
+		}
+
+		if article.ModifiedTime == nil || article.ModifiedTime.Format(time.RFC3339) != "2021-03-05T12:30:00Z" {
+			t.Errorf("\n%s: want modified time 2021-03-05T12:30:00Z, got %v", name, article.ModifiedTime)
+		}
+	}
+}
+
+func Test_articleWordCount(t *testing.T) {
+	rawHTML := `<html><body><article><h1>Title</h1>` + testParagraph +
+		"<p>Spaced    out\n\n   words\t\there.</p>" + testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if want := 2*12*8 + 4; article.WordCount != want {
+		t.Errorf("want %d words, got %d", want, article.WordCount)
+	}
+
+	if article.WordCount != len(strings.Fields(article.TextContent)) {
+		t.Errorf("word count %d doesn't match text content %q", article.WordCount, article.TextContent)
+	}
+}
+
+func Test_authors(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		head    string
+		authors []string
+		byline  string
+	}{
+		{"json-ld list", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","author":[` +
+			`{"@type":"Person","name":"Jane Doe"},{"@type":"Person","name":"John Roe"},"Max Moe"]}</script>`,
+			[]string{"Jane Doe", "John Roe", "Max Moe"}, "Jane Doe, John Roe, Max Moe"},
+		{"json-ld graph", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","author":{"@graph":[` +
+			`{"@type":"Person","name":"Jane Doe"},{"@type":"Person","name":"Jane Doe"},{"@type":"Person","name":"John Roe"}]}}</script>`,
+			[]string{"Jane Doe", "John Roe"}, "Jane Doe, John Roe"},
+		{"json-ld string", `<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","author":"Jane Doe"}</script>`,
+			[]string{"Jane Doe"}, "Jane Doe"},
+		{"meta", `<meta name="author" content="Jane Doe and John Roe">`,
+			[]string{"Jane Doe and John Roe"}, "Jane Doe and John Roe"},
+		{"none", ``, nil, ""},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := articleHTML(scenario.head, "")
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if strings.Join(article.Authors, "|") != strings.Join(scenario.authors, "|") {
+			t.Errorf("\n%s: want authors %q, got %q", scenario.name, scenario.authors, article.Authors)
+		}
+
+		if article.Byline != scenario.byline {
+			t.Errorf("\n%s: want byline %q, got %q", scenario.name, scenario.byline, article.Byline)
+		}
+	}
+}
+
+func Test_fixRelativeURIs(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p><a href="other.html">relative</a> <a href="/root.html">root-relative</a> ` +
+		`<a href="//cdn.example.com/doc.html">protocol-relative</a> <a href="https://example.com/abs.html">absolute</a></p>` +
+		`<p><img src="img/photo.jpg"></p>` +
+		`<video src="/media/clip.mp4" poster="poster.jpg"><source src="//cdn.example.com/clip.webm"></video>` +
+		`<audio src="https://example.com/sound.mp3"></audio>` +
+		`<picture><source srcset="img/wide.jpg 1024w, /img/narrow.jpg 480w"><img src="img/fallback.jpg"></picture>` +
+		testParagraph + `</article></body></html>`
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	for _, wanted := range []string{
+		`href="http://fakehost/test/other.html"`,
+		`href="http://fakehost/root.html"`,
+		`href="http://cdn.example.com/doc.html"`,
+		`href="https://example.com/abs.html"`,
+		`src="http://fakehost/test/img/photo.jpg"`,
+		`src="http://fakehost/media/clip.mp4"`,
+		`poster="http://fakehost/test/poster.jpg"`,
+		`src="http://cdn.example.com/clip.webm"`,
+		`src="https://example.com/sound.mp3"`,
+		`srcset="http://fakehost/test/img/wide.jpg 1024w, http://fakehost/img/narrow.jpg 480w"`,
+		`src="http://fakehost/test/img/fallback.jpg"`,
+	} {
+		if !strings.Contains(article.Content, wanted) {
+			t.Errorf("\nwant %s, got %s", wanted, article.Content)
+		}
+	}
+
+	// Without page URL, the links are left as they are.
+	parser := NewParser()
+	article, err := parser.Parse(strings.NewReader(rawHTML), nil)
+	if err != nil {
+		t.Fatalf("\nfailed to parse HTML: %v", err)
+	}
+
+	for _, wanted := range []string{
+		`href="other.html"`,
+		`href="/root.html"`,
+		`href="//cdn.example.com/doc.html"`,
+		`src="img/photo.jpg"`,
+		`srcset="img/wide.jpg 1024w, /img/narrow.jpg 480w"`,
+	} {
+		if !strings.Contains(article.Content, wanted) {
+			t.Errorf("\nwithout page URL: want %s, got %s", wanted, article.Content)
+		}
+	}
+}
+
+func Test_classesToPreserve(t *testing.T) {
+	rawHTML := `<html><body><article>` + testParagraph +
+		`<p class="lead">The formula <span class="math inline">E = mc^2</span> is famous.</p>` +
+		testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.ClassesToPreserve = []string{"math"}
+	article := parseHTMLString(t, parser, rawHTML)
+	for _, wanted := range []string{`<span class="math">E = mc^2</span>`, `class="page"`} {
+		if !strings.Contains(article.Content, wanted) {
+			t.Errorf("\nwant %s, got %s", wanted, article.Content)
+		}
+	}
+
+	for _, unwanted := range []string{"inline", `class="lead"`} {
+		if strings.Contains(article.Content, unwanted) {
+			t.Errorf("\nwant %s removed, got %s", unwanted, article.Content)
+		}
+	}
+}
+
+func Test_keepAttributes(t *testing.T) {
+	rawHTML := `<html><body><article>` +
+		`<p>Jump to <a href="#step-3">step 3</a>.</p>` +
+		testParagraph + `<h2 id="step-3" style="color: red">Step 3</h2>` + testParagraph +
+		`<h2 id="unreferenced" align="center">Step 4</h2>` + testParagraph + `</article></body></html>`
+
+	parser := NewParser()
+	parser.CleanIDs = true
+	parser.KeepAttributes = []string{"ID", "Style"}
+	article := parseHTMLString(t, parser, rawHTML)
+
+	// The fragment links must still resolve to their targets.
+	for _, link := range dom.GetElementsByTagName(article.Node, "a") {
+		id := strings.TrimPrefix(dom.GetAttribute(link, "href"), "#")
+		if dom.GetElementByID(article.Node, id) == nil {
+			t.Errorf("\nwant target of %s, got %s", dom.GetAttribute(link, "href"), article.Content)
+		}
+	}
+
+	for _, expected := range []string{`id="unreferenced"`, `style="color: red"`} {
+		if !strings.Contains(article.Content, expected) {
+			t.Errorf("\nwant %s kept, got %s", expected, article.Content)
+		}
+	}
+
+	if strings.Contains(article.Content, `align="center"`) {
+		t.Errorf("\nwant unlisted attribute removed, got %s", article.Content)
+	}
+}
+
+func Test_charThresholds(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat(testSentence, 5) + "</p>"
+	rawHTML := `<html><body><div class="sidebar"><p>` + strings.Repeat("Popular stories from around the site, updated daily. ", 10) + `</p></div>` +
+		`<article>` + paragraph + `</article></body></html>`
+
+	parser := NewParser()
+	article := parseHTMLString(t, parser, rawHTML)
+	if !strings.Contains(article.TextContent, "Popular stories") {
+		t.Errorf("\nwant short article to fail the default threshold, so the sidebar is kept, got %s", article.Content)
+	}
+
+	parser.CharThresholds = 200
+	article = parseHTMLString(t, parser, rawHTML)
+	if !strings.Contains(article.TextContent, "Lorem ipsum") || strings.Contains(article.TextContent, "Popular stories") {
+		t.Errorf("\nwant only the short article, got %s", article.Content)
+	}
+}
+
+func Test_siblingScoreThreshold(t *testing.T) {
+	paragraph := "<p>" + strings.Repeat("Lorem ipsum, dolor sit amet, consectetur adipiscing elit. ", 6) + "</p>"
+	rawHTML := `<html><body><div>` +
+		`<div>` + strings.Repeat(paragraph, 30) + `</div>` +
+		`<div><p>` + strings.Repeat("Strong sibling, with enough text to score. ", 8) + `</p>` + strings.Repeat(paragraph, 7) + `</div>` +
+		`<div><p>` + strings.Repeat("Weak sibling, with a bit of text to score. ", 8) + `</p>` + strings.Repeat(paragraph, 2) + `</div>` +
+		`</div></body></html>`
+
+	scenarios := []struct {
+		threshold float64
+		strong    bool
+		weak      bool
+	}{
+		{0, true, false},
+		{0.05, true, true},
+		{0.5, false, false},
+	}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		parser.SiblingScoreThreshold = scenario.threshold
+		article := parseHTMLString(t, parser, rawHTML)
+
+		strong := strings.Contains(article.TextContent, "Strong sibling")
+		weak := strings.Contains(article.TextContent, "Weak sibling")
+		if strong != scenario.strong || weak != scenario.weak {
+			t.Errorf("\n"+
+				"threshold : %v\n"+
+				"want      : strong %v, weak %v\n"+
+				"got       : strong %v, weak %v", scenario.threshold, scenario.strong, scenario.weak, strong, weak)
+		}
+	}
+}
+
+func Test_nTopCandidates(t *testing.T) {
+	source, err := ioutil.ReadFile(fp.Join("test-pages", "ars-1", "source.html"))
+	if err != nil {
+		t.Fatalf("\nfailed to read test file: %v", err)
+	}
+
+	defaultArticle := parseHTMLString(t, NewParser(), string(source))
+	for _, nTopCandidates := range []int{1, 0, -1} {
+		parser := NewParser()
+		parser.NTopCandidates = nTopCandidates
+		article := parseHTMLString(t, parser, string(source))
+		if article.Node == nil || article.Length < defaultCharThresholds {
+			t.Errorf("\nNTopCandidates %d: want article to be parsed, got %q", nTopCandidates, article.TextContent)
+		}
+
+		// Zero or negative value falls back to the default.
+		if nTopCandidates <= 0 && article.Content != defaultArticle.Content {
+			t.Errorf("\nNTopCandidates %d: want the same content as default", nTopCandidates)
+		}
+	}
+}
+
+func Test_favicon(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		head     string
+		expected string
+	}{
+		{"largest of competing icons", `<link rel="icon" href="/favicon-32.png" sizes="32x32">` +
+			`<link rel="icon" type="image/png" sizes="192x192" href="icons/android.png">` +
+			`<link rel="apple-touch-icon" href="/apple-touch-icon.png">` +
+			`<link rel="icon" sizes="300x150" href="/banner.png">`,
+			"http://fakehost/test/icons/android.png"},
+		{"apple touch icon without size", `<link rel="shortcut icon" href="/favicon.ico">` +
+			`<link rel="mask-icon" href="/mask.svg" color="#000">` +
+			`<link rel="apple-touch-icon" href="/apple-touch-icon.png">`,
+			"http://fakehost/apple-touch-icon.png"},
+		{"multiple sizes", `<link rel="icon" href="/favicon.ico" sizes="16x16 48x48">` +
+			`<link rel="icon" href="/favicon-32.png" sizes="32x32">`,
+			"http://fakehost/favicon.ico"},
+		{"shortcut icon", `<link rel="shortcut icon" href="//cdn.example.com/favicon.ico">`,
+			"http://cdn.example.com/favicon.ico"},
+		{"no icon", ``, "http://fakehost/favicon.ico"},
+	}
+
+	for _, scenario := range scenarios {
+		rawHTML := articleHTML(scenario.head, "")
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Favicon != scenario.expected {
+			t.Errorf("\n%s: want favicon %q, got %q", scenario.name, scenario.expected, article.Favicon)
+		}
+	}
+}
+
+func Test_canonicalURL(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		head     string
+		expected string
+	}{
+		{"canonical link", `<link rel="canonical" href="/articles/story">` +
+			`<meta property="og:url" content="https://example.com/og/story">`,
+			"http://fakehost/articles/story"},
+		{"og:url fallback", `<meta property="og:url" content="https://example.com/og/story">`,
+			"https://example.com/og/story"},
+		{"none", ``, ""},
+	}
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html?utm_source=feed")
+	for _, scenario := range scenarios {
+		rawHTML := articleHTML(scenario.head, "")
+		parser := NewParser()
+		article, err := parser.Parse(strings.NewReader(rawHTML), pageURL)
+		if err != nil {
+			t.Fatalf("\n%s: failed to parse HTML: %v", scenario.name, err)
+		}
+
+		if article.CanonicalURL != scenario.expected {
+			t.Errorf("\n%s: want canonical URL %q, got %q", scenario.name, scenario.expected, article.CanonicalURL)
+		}
+	}
+}
+
+func Test_concurrentParseDocument(t *testing.T) {
+	testNames := []string{"ars-1", "bbc-1", "medium-3", "wikipedia", "mercurial"}
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+
+	// Parse every document sequentially for the expected results.
+	docs := make([]*html.Node, len(testNames))
+	expected := make([]string, len(testNames))
+	for i, testName := range testNames {
+		f, err := os.Open(fp.Join("test-pages", testName, "source.html"))
+		if err != nil {
+			t.Fatalf("\nfailed to open test file: %v", err)
+		}
+
+		docs[i], err = html.Parse(f)
+		f.Close()
+		if err != nil {
+			t.Fatalf("\nfailed to parse test file: %v", err)
+		}
+
+		parser := NewParser()
+		article, err := parser.ParseDocument(docs[i], pageURL)
+		if err != nil {
+			t.Fatalf("\n%s: failed to parse document: %v", testName, err)
+		}
+		expected[i] = article.Title + "\n" + article.Content
+	}
+
+	// Then parse them at once using the same parser. Run with -race to
+	// make sure the parses don't share any state.
+	parser := NewParser()
+	results := make([]string, len(testNames)*4)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			article, err := parser.ParseDocument(docs[i%len(docs)], pageURL)
+			if err != nil {
+				t.Errorf("\n%s: failed to parse document: %v", testNames[i%len(docs)], err)
+				return
+			}
+			results[i] = article.Title + "\n" + article.Content
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if result != expected[i%len(docs)] {
+			t.Errorf("\n%s: want the same result as sequential parse", testNames[i%len(docs)])
+		}
+	}
+}
+
+// countdownContext is context which is canceled after its Err has been
+// checked for the specified times.
+type countdownContext struct {
+	context.Context
+	remaining int
+}
+
+func (ctx *countdownContext) Err() error {
+	if ctx.remaining <= 0 {
+		return context.Canceled
+	}
+	ctx.remaining--
+	return nil
+}
+
+func Test_parseContext(t *testing.T) {
+	source, err := ioutil.ReadFile(fp.Join("test-pages", "wikipedia", "source.html"))
+	if err != nil {
+		t.Fatalf("\nfailed to read test file: %v", err)
+	}
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	scenarios := map[string]context.Context{
+		"already canceled":     &countdownContext{Context: context.Background()},
+		"canceled in metadata": &countdownContext{Context: context.Background(), remaining: 2},
+		"canceled in grabbing": &countdownContext{Context: context.Background(), remaining: 500},
+	}
+
+	for name, ctx := range scenarios {
+		parser := NewParser()
+		article, err := parser.ParseContext(ctx, strings.NewReader(string(source)), pageURL)
+		if err != context.Canceled {
+			t.Errorf("\n%s: want error %v, got %v", name, context.Canceled, err)
+		}
+
+		if article.Node != nil || article.Title != "" {
+			t.Errorf("\n%s: want empty article, got %q", name, article.Title)
+		}
+	}
+
+	// Context that never canceled must give the same result as Parse.
+	parser := NewParser()
+	want, _ := parser.Parse(strings.NewReader(string(source)), pageURL)
+	got, err := parser.ParseContext(context.Background(), strings.NewReader(string(source)), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse with context: %v", err)
+	}
+
+	if got.Content != want.Content {
+		t.Errorf("\nwant the same content as Parse")
+	}
+}
+
+func Test_getJSONLD(t *testing.T) {
+	head := `<title>Page title</title>` +
+		`<script type="application/ld+json">{"@context": "https://schema.org", "headline": </script>` +
+		`<script type="application/ld+json">{"@context":"https://schema.org","@type":"BreadcrumbList",` +
+		`"itemListElement":[{"@type":"ListItem","position":1,"name":"News"}]}</script>` +
+		`<script type="application/ld+json">{"@context":"https://schema.org","@graph":[` +
+		`{"@type":"WebSite","name":"Example Site"},` +
+		`{"@type":"WebPage","name":"Web page name"},` +
+		`{"@type":["NewsArticle","Article"],"headline":"Graph headline",` +
+		`"author":{"@type":"Person","name":"Jane Doe"},` +
+		`"datePublished":"2021-03-04T10:00:00Z","dateModified":"2021-03-05T12:30:00Z"}]}</script>`
+	rawHTML := articleHTML(head, "")
+
+	article := parseHTMLString(t, NewParser(), rawHTML)
+	if article.Title != "Graph headline" {
+		t.Errorf("\nwant title %q, got %q", "Graph headline", article.Title)
+	}
+
+	if article.Byline != "Jane Doe" {
+		t.Errorf("\nwant byline %q, got %q", "Jane Doe", article.Byline)
+	}
+
+	expectedPublished := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	if article.PublishedTime == nil || !article.PublishedTime.Equal(expectedPublished) {
+		t.Errorf("\nwant published time %v, got %v", expectedPublished, article.PublishedTime)
+	}
+
+	expectedModified := time.Date(2021, 3, 5, 12, 30, 0, 0, time.UTC)
+	if article.ModifiedTime == nil || !article.ModifiedTime.Equal(expectedModified) {
+		t.Errorf("\nwant modified time %v, got %v", expectedModified, article.ModifiedTime)
+	}
+
+	// Article in other context than schema.org is ignored.
+	rawHTML = articleHTML(`<title>Page title</title><script type="application/ld+json">`+
+		`{"@context":"https://example.com","@type":"Article","headline":"Other headline"}</script>`, "")
+	if article := parseHTMLString(t, NewParser(), rawHTML); article.Title != "Page title" {
+		t.Errorf("\nwant title %q, got %q", "Page title", article.Title)
+	}
+}
+
+func Test_jsonLDImage(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		image    string
+		meta     string
+		expected string
+	}{{
+		name:     "string",
+		image:    `"/images/cover.jpg"`,
+		expected: "http://fakehost/images/cover.jpg",
+	}, {
+		name:     "array of strings",
+		image:    `["images/first.jpg", "images/second.jpg"]`,
+		expected: "http://fakehost/test/images/first.jpg",
+	}, {
+		name:     "ImageObject",
+		image:    `{"@type":"ImageObject","url":"https://cdn.example.com/cover.jpg","width":1200,"height":630}`,
+		expected: "https://cdn.example.com/cover.jpg",
+	}, {
+		name: "array of ImageObject",
+		image: `[{"@type":"ImageObject","url":"/small.jpg","width":"300","height":"200"},` +
+			`{"@type":"ImageObject","contentUrl":"/large.jpg","width":1200,"height":800},` +
+			`{"@type":"ImageObject","url":"/unknown.jpg"}]`,
+		expected: "http://fakehost/large.jpg",
+	}, {
+		name:     "meta is preferred",
+		image:    `"/images/cover.jpg"`,
+		meta:     `<meta property="og:image" content="/images/og.jpg">`,
+		expected: "http://fakehost/images/og.jpg",
+	}}
+
+	for _, scenario := range scenarios {
+		rawHTML := `<html><head>` + scenario.meta + `<script type="application/ld+json">` +
+			`{"@context":"https://schema.org","@type":"NewsArticle","headline":"Title","image":` + scenario.image + `}` +
+			`</script></head><body><article>` + testParagraph + testParagraph + `</article></body></html>`
+
+		article := parseHTMLString(t, NewParser(), rawHTML)
+		if article.Image != scenario.expected {
+			t.Errorf("\n%s: want image %q, got %q", scenario.name, scenario.expected, article.Image)
+		}
+	}
+}
+
+func Test_extractHooks(t *testing.T) {
+	story := strings.Repeat("<p>"+strings.Repeat("The main story continues with more details here. ", 6)+"</p>", 2)
+	teasers := strings.Repeat("<p>Read more of our related teasers</p>", 4)
+	rawHTML := `<html><body><section>` + story + `</section><div class="teasers">` + teasers + `</div></body></html>`
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+
+	doc, _ := dom.Parse(strings.NewReader(rawHTML))
+	article, _ := FromDocument(doc, pageURL)
+	if !strings.Contains(article.TextContent, "related teasers") {
+		t.Fatalf("\nwant teasers extracted without hook, got %q", article.TextContent)
+	}
+
+	parser := NewParser()
+	parser.PreExtractHook = func(doc *html.Node) {
+		dom.RemoveNodes(dom.QuerySelectorAll(doc, ".teasers"), nil)
+	}
+	parser.PostExtractHook = func(content *html.Node) {
+		attribution := dom.CreateElement("p")
+		dom.SetTextContent(attribution, "Originally published by Fakehost.")
+		dom.AppendChild(content, attribution)
+	}
+
+	article, err := parser.ParseDocument(doc, pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse document: %v", err)
+	}
+
+	if strings.Contains(article.TextContent, "related teasers") {
+		t.Errorf("\nwant teasers removed by pre-extract hook, got %q", article.TextContent)
+	}
+
+	if !strings.Contains(article.TextContent, "Originally published by Fakehost.") {
+		t.Errorf("\nwant attribution added by post-extract hook, got %q", article.TextContent)
+	}
+
+	// The hooks work on a copy, so the original document is untouched.
+	if len(dom.QuerySelectorAll(doc, ".teasers")) != 1 {
+		t.Errorf("\nwant the original document unchanged")
+	}
+}
+
+func Test_orderedLists(t *testing.T) {
+	article := parseTestPage(t, NewParser(), "ordered-lists")
+
+	lists := dom.QuerySelectorAll(article.Node, "ol")
+	if len(lists) != 3 {
+		t.Fatalf("\nwant 3 ordered lists, got %d: %s", len(lists), article.Content)
+	}
+
+	if start := dom.GetAttribute(lists[0], "start"); start != "5" {
+		t.Errorf("\nwant list starting at 5, got start %q", start)
+	}
+
+	nested := dom.QuerySelectorAll(article.Node, "ol > li > ol, ol > li > ul")
+	if len(nested) != 2 {
+		t.Fatalf("\nwant 2 lists nested in list items, got %d: %s", len(nested), article.Content)
+	}
+
+	if listType := dom.GetAttribute(nested[0], "type"); listType != "a" {
+		t.Errorf("\nwant nested list of type a, got %q", listType)
+	}
+
+	if items := dom.QuerySelectorAll(nested[0], "li"); len(items) != 3 {
+		t.Errorf("\nwant 3 items in nested list, got %d", len(items))
+	}
+}
+
+func Test_definitionLists(t *testing.T) {
+	article := parseTestPage(t, NewParser(), "definition-list")
+
+	terms := []string{"Aperture", "Bokeh", "Exposure triangle", "Focal length", "ISO"}
+	definitions := []string{
+		"The opening in the lens that lets light in.",
+		"The look of the out-of-focus areas.",
+		"How aperture, shutter speed and ISO relate.",
+		"Decides how wide the field of view is.",
+		"The sensitivity of the sensor to light.",
+	}
+
+	for _, term := range terms {
+		if !strings.Contains(article.Content, ">"+term+"</a></dt>") {
+			t.Errorf("\nwant term %q in content, got %s", term, article.Content)
+		}
+	}
+
+	for _, definition := range definitions {
+		if !strings.Contains(article.Content, "<dd>"+definition+"</dd>") {
+			t.Errorf("\nwant definition %q in content, got %s", definition, article.Content)
+		}
+	}
+
+	// The empty definition list is removed.
+	if lists := dom.GetElementsByTagName(article.Node, "dl"); len(lists) != 1 {
+		t.Errorf("\nwant 1 definition list, got %d", len(lists))
+	}
+}
+
+func Test_fixLazyImages(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		img        string
+		attributes []string
+		expected   string
+	}{{
+		name:     "data-src over empty src",
+		img:      `<img data-src="real.jpg">`,
+		expected: "http://fakehost/test/real.jpg",
+	}, {
+		name:     "data-original over data URI",
+		img:      `<img src="data:image/gif;base64,R0lGODlhAQABAIAAAAAAAP///yH5BAEAAAAALAAAAAABAAEAAAIBRAA7" data-original="real.jpg">`,
+		expected: "http://fakehost/test/real.jpg",
+	}, {
+		name:     "data-lazy-src over spacer",
+		img:      `<img src="/assets/spacer.gif" data-lazy-src="real.jpg">`,
+		expected: "http://fakehost/test/real.jpg",
+	}, {
+		name:     "data-src over 1x1 image",
+		img:      `<img src="/assets/p.gif" width="1" height="1" data-src="real.jpg">`,
+		expected: "http://fakehost/test/real.jpg",
+	}, {
+		name:     "real src is kept",
+		img:      `<img src="real.jpg" data-src="other.jpg">`,
+		expected: "http://fakehost/test/real.jpg",
+	}, {
+		name:       "custom attribute",
+		img:        `<img src="/assets/blank.gif" data-full="real.jpg" data-src="other.jpg">`,
+		attributes: []string{"data-full"},
+		expected:   "http://fakehost/test/real.jpg",
+	}}
+
+	for _, scenario := range scenarios {
+		parser := NewParser()
+		if scenario.attributes != nil {
+			parser.LazyImageAttributes = scenario.attributes
+		}
+
+		rawHTML := `<html><body><article>` + testParagraph + scenario.img + testParagraph + `</article></body></html>`
+		article := parseHTMLString(t, parser, rawHTML)
+		images := dom.GetElementsByTagName(article.Node, "img")
+		if len(images) != 1 {
+			t.Errorf("\n%s: want 1 image, got %s", scenario.name, article.Content)
+			continue
+		}
+
+		if src := dom.GetAttribute(images[0], "src"); src != scenario.expected {
+			t.Errorf("\n%s: want src %q, got %q", scenario.name, scenario.expected, src)
+		}
+	}
+}
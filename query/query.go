@@ -0,0 +1,32 @@
+// Package query wraps the readable content extracted by go-readability
+// into goquery, so it can be queried and manipulated right away.
+//
+// It's kept as separate package so goquery is only compiled by the
+// programs that use it.
+package query
+
+import (
+	"github.com/PuerkitoBio/goquery"
+	readability "github.com/alaaelgndy/go-readability"
+)
+
+// Selection returns the readable node of article as goquery selection.
+// If the article doesn't have any readable node, the selection is empty.
+func Selection(article readability.Article) *goquery.Selection {
+	if article.Node == nil {
+		return &goquery.Selection{}
+	}
+
+	return goquery.NewDocumentFromNode(article.Node).Selection
+}
+
+// Document returns the readable node of article as goquery document,
+// whose root is the readable node. Returns nil if the article doesn't
+// have any readable node.
+func Document(article readability.Article) *goquery.Document {
+	if article.Node == nil {
+		return nil
+	}
+
+	return goquery.NewDocumentFromNode(article.Node)
+}
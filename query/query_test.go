@@ -0,0 +1,34 @@
+package query
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	readability "github.com/alaaelgndy/go-readability"
+)
+
+func Test_Selection(t *testing.T) {
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	paragraph := "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 12) + "</p>"
+	rawHTML := `<html><body><article>` + paragraph +
+		`<p>Read the <a href="/docs">docs</a>.</p>` + paragraph + `</article></body></html>`
+
+	article, err := readability.FromReader(strings.NewReader(rawHTML), pageURL)
+	if err != nil {
+		t.Fatalf("\nfailed to parse HTML: %v", err)
+	}
+
+	selection := Selection(article)
+	if count := selection.Find("p").Length(); count != 3 {
+		t.Errorf("\nwant 3 paragraphs, got %d", count)
+	}
+
+	if href, _ := selection.Find("a").Attr("href"); href != "http://fakehost/docs" {
+		t.Errorf("\nwant resolved link, got %q", href)
+	}
+
+	if Selection(readability.Article{}).Length() != 0 {
+		t.Errorf("\nwant empty selection for article without node")
+	}
+}
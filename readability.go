@@ -9,6 +9,7 @@
 package readability
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,8 +20,20 @@ import (
 	"golang.org/x/net/html"
 )
 
+// ErrUnsupportedContentType is returned when the input is not a HTML
+// document, e.g. PDF, image or JSON.
+var ErrUnsupportedContentType = errors.New("unsupported content type")
+
+// ErrNoContent is returned when the page has too little visible text
+// to contain any article, e.g. error pages and login walls.
+var ErrNoContent = errors.New("page has no content")
+
 var proxyURL string
 
+// defaultUserAgent is the User-Agent sent by FromURL. Some sites reject
+// the requests without User-Agent, or with the default one of Go.
+const defaultUserAgent = "Mozilla/5.0 (compatible; go-readability; +https://github.com/alaaelgndy/go-readability)"
+
 func SetProxies(proxies string) {
 	proxyURL = proxies
 }
@@ -39,8 +52,26 @@ func FromDocument(doc *html.Node, pageURL *nurl.URL) (Article, error) {
 	return parser.ParseDocument(doc, pageURL)
 }
 
+// CleanContent runs the cleaning passes of readability on HTML content
+// that already known to be the article. It's the wrapper for
+// `Parser.CleanContent()` and useful if you only use the default parser.
+func CleanContent(contentHTML string, pageURL *nurl.URL) (string, error) {
+	parser := NewParser()
+	return parser.CleanContent(contentHTML, pageURL)
+}
+
+// ParseMetadataFast extracts only the metadata of the page, without
+// building DOM of the whole page. It's the wrapper for
+// `Parser.ParseMetadataFast()` and useful for link previews.
+func ParseMetadataFast(input io.Reader, pageURL *nurl.URL) (Article, error) {
+	parser := NewParser()
+	return parser.ParseMetadataFast(input, pageURL)
+}
+
 // FromURL fetch the web page from specified url then parses the response to find
-// the readable content.
+// the readable content. The redirects are followed, and the relative URLs in the
+// content are resolved against the final URL. Returns error if the server doesn't
+// respond with 2xx status.
 func FromURL(pageURL string, timeout time.Duration) (Article, error) {
 	// Make sure URL is valid
 	parsedURL, err := nurl.ParseRequestURI(pageURL)
@@ -65,16 +96,31 @@ func FromURL(pageURL string, timeout time.Duration) (Article, error) {
 		}
 	}
 
-	resp, err := client.Get(pageURL)
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return Article{}, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return Article{}, fmt.Errorf("failed to fetch the page: %v", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Article{}, fmt.Errorf("failed to fetch the page: server responded with %s", resp.Status)
+	}
+
+	// Use the final URL after redirects
+	if resp.Request != nil && resp.Request.URL != nil {
+		parsedURL = resp.Request.URL
+	}
+
 	// Make sure content type is HTML
 	cp := resp.Header.Get("Content-Type")
-	if !strings.Contains(cp, "text/html") {
-		return Article{}, fmt.Errorf("URL is not a HTML document")
+	if !strings.Contains(cp, "text/html") && !strings.Contains(cp, "application/xhtml+xml") {
+		return Article{}, fmt.Errorf("%w: %s", ErrUnsupportedContentType, cp)
 	}
 
 	// Parse content
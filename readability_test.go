@@ -0,0 +1,80 @@
+package readability
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_unsupportedContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer server.Close()
+
+	_, err := FromURL(server.URL, 5*time.Second)
+	if !errors.Is(err, ErrUnsupportedContentType) {
+		t.Errorf("\nwant ErrUnsupportedContentType from URL, got %v", err)
+	}
+
+	pageURL, _ := url.ParseRequestURI("http://fakehost/test/page.html")
+	scenarios := map[string][]byte{
+		"png":  {0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n', 0, 0, 0, 0x0D},
+		"pdf":  []byte("%PDF-1.4\n%\xe2\xe3\xcf\xd3"),
+		"json": []byte(`  {"title": "not a web page"}`),
+	}
+
+	for name, input := range scenarios {
+		_, err := FromReader(bytes.NewReader(input), pageURL)
+		if !errors.Is(err, ErrUnsupportedContentType) {
+			t.Errorf("\n%s: want ErrUnsupportedContentType, got %v", name, err)
+		}
+	}
+
+	_, err = FromReader(bytes.NewReader([]byte("<p>[1] a footnote</p>")), pageURL)
+	if err != nil {
+		t.Errorf("\nwant HTML to be parsed, got %v", err)
+	}
+}
+
+func Test_FromURL(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old/article":
+			http.Redirect(w, r, "/new/article", http.StatusMovedPermanently)
+		case "/new/article":
+			userAgent = r.Header.Get("User-Agent")
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(`<html><body><article>` + testParagraph +
+				`<p><img src="image.jpg"></p>` + testParagraph + `</article></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	article, err := FromURL(server.URL+"/old/article", 5*time.Second)
+	if err != nil {
+		t.Fatalf("\nfailed to parse redirected page: %v", err)
+	}
+
+	if want := server.URL + "/new/image.jpg"; !strings.Contains(article.Content, want) {
+		t.Errorf("\nwant image resolved against final URL %q, got content:\n%s", want, article.Content)
+	}
+
+	if userAgent != defaultUserAgent {
+		t.Errorf("\nwant User-Agent %q, got %q", defaultUserAgent, userAgent)
+	}
+
+	_, err = FromURL(server.URL+"/missing", 5*time.Second)
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("\nwant error with 404 status, got %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package readability
 
 import (
+	"fmt"
 	nurl "net/url"
 	"os"
+	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/sirupsen/logrus"
@@ -32,6 +35,34 @@ func charCount(str string) int {
 	return utf8.RuneCountInString(str)
 }
 
+// truncateText cuts str at the last word boundary and appends ellipsis
+// to it, so it's at most maxLength chars including the ellipsis. If
+// there's no word boundary, e.g. in Chinese text, it's cut right at the
+// limit. The str is returned as it is if it's not longer than maxLength,
+// or maxLength is zero.
+func truncateText(str string, maxLength int) string {
+	runes := []rune(str)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return str
+	}
+
+	// Reserve one char for the ellipsis.
+	cut := maxLength - 1
+	if !unicode.IsSpace(runes[cut]) {
+		for i := cut - 1; i > 0; i-- {
+			if unicode.IsSpace(runes[i]) {
+				cut = i
+				break
+			}
+		}
+	}
+
+	truncated := strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:-", r)
+	})
+	return truncated + "…"
+}
+
 // isValidURL checks if URL is valid.
 func isValidURL(s string) bool {
 	_, err := nurl.ParseRequestURI(s)
@@ -40,11 +71,14 @@ func isValidURL(s string) bool {
 
 // toAbsoluteURI convert uri to absolute path based on base.
 // However, if uri is prefixed with hash (#), the uri won't be changed.
+// Like browsers, the surrounding whitespaces of uri are ignored.
 func toAbsoluteURI(uri string, base *nurl.URL) string {
 	if uri == "" || base == nil {
 		return uri
 	}
 
+	uri = strings.TrimSpace(uri)
+
 	// If it is hash tag, return as it is
 	if strings.HasPrefix(uri, "#") {
 		return uri
@@ -70,6 +104,82 @@ func toAbsoluteURI(uri string, base *nurl.URL) string {
 	return base.ResolveReference(tmp).String()
 }
 
+// isUnsafeURL checks if uri is able to run script when the content is
+// rendered, i.e. it uses javascript: or vbscript: scheme, or it's a
+// data: URI whose media type is not a raster image. SVG is excluded
+// since it might carry script. Like browsers, control chars and
+// whitespaces are ignored while checking the scheme.
+func isUnsafeURL(uri string) bool {
+	uri = normalizeURLScheme(uri)
+
+	switch {
+	case strings.HasPrefix(uri, "javascript:"), strings.HasPrefix(uri, "vbscript:"):
+		return true
+	case strings.HasPrefix(uri, "data:"):
+		return !strings.HasPrefix(uri, "data:image/") || strings.HasPrefix(uri, "data:image/svg")
+	default:
+		return false
+	}
+}
+
+// isUnsafeEmbedURL is like isUnsafeURL, but for the URI which loaded as
+// document by frame and embed elements. Every data: URI is unsafe there,
+// including the images.
+func isUnsafeEmbedURL(uri string) bool {
+	return isUnsafeURL(uri) || strings.HasPrefix(normalizeURLScheme(uri), "data:")
+}
+
+// normalizeURLScheme removes control chars and whitespaces from uri and
+// converts it to lowercase, so its scheme can be checked the same way
+// browsers do.
+func normalizeURLScheme(uri string) string {
+	uri = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, uri)
+	return strings.ToLower(uri)
+}
+
+// normalizeColor converts CSS color in hex (#rgb or #rrggbb), rgb()
+// or basic color keyword into lowercase #rrggbb format. Returns empty
+// string if the color is not recognized.
+func normalizeColor(color string) string {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if hex, isKeyword := basicColorKeywords[color]; isKeyword {
+		return hex
+	}
+
+	if strings.HasPrefix(color, "#") {
+		hex := color[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		} else if len(hex) == 8 {
+			hex = hex[:6]
+		}
+
+		if _, err := strconv.ParseUint(hex, 16, 32); err != nil || len(hex) != 6 {
+			return ""
+		}
+		return "#" + hex
+	}
+
+	if parts := rxRGBColor.FindStringSubmatch(color); parts != nil {
+		hex := "#"
+		for _, part := range parts[1:4] {
+			value, err := strconv.Atoi(part)
+			if err != nil || value > 255 {
+				return ""
+			}
+			hex += fmt.Sprintf("%02x", value)
+		}
+		return hex
+	}
+
+	return ""
+}
+
 // renderToFile ender an element and save it to file.
 // It will panic if it fails to create destination file.
 func renderToFile(element *html.Node, filename string) {
@@ -91,6 +201,57 @@ func strOr(args ...string) string {
 	return ""
 }
 
+// cp1252Bytes maps characters of Windows-1252 whose code point is
+// different with their byte value (0x80 - 0x9F) back to the byte.
+var cp1252Bytes = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86,
+	'‡': 0x87, 'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C,
+	'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95,
+	'–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// repairMojibake reverses text that encoded as UTF-8, wrongly decoded
+// as Latin-1 or Windows-1252, then encoded again as UTF-8 (e.g. "Ã©"
+// for "é"). To avoid corrupting correct text, str is only changed if it
+// has mojibake pattern, every character in it fit in a single byte and
+// those bytes are a valid UTF-8 text.
+func repairMojibake(str string) string {
+	if !rxMojibake.MatchString(str) {
+		return str
+	}
+
+	bytes := make([]byte, 0, len(str))
+	for _, r := range str {
+		if r < 0x100 {
+			bytes = append(bytes, byte(r))
+		} else if b, exist := cp1252Bytes[r]; exist {
+			bytes = append(bytes, b)
+		} else {
+			return str
+		}
+	}
+
+	if !utf8.Valid(bytes) {
+		return str
+	}
+
+	return string(bytes)
+}
+
+// repairedOffset converts byte offset in str into the offset in the
+// text returned by repairMojibake, where every char of str becomes a
+// single byte. The offset is clamped into str.
+func repairedOffset(str string, offset int) int {
+	if offset < 0 {
+		return 0
+	}
+	if offset > len(str) {
+		offset = len(str)
+	}
+	return utf8.RuneCountInString(str[:offset])
+}
+
 func sliceToMap(strings ...string) map[string]struct{} {
 	result := make(map[string]struct{})
 	for _, s := range strings {
@@ -34,6 +34,8 @@ func Test_wordCount(t *testing.T) {
 		"German fashion designer Karl Lagerfeld, best known for his creative work at Chanel, dies at the age of 85.":       19,
 		"A suicide bombing attack near Pulwama, in Indian administered Kashmir, kills 40 security personnel.":              14,
 		"NASA concludes the 15 year Opportunity Mars rover mission after being unable to wake the rover from hibernation.": 18,
+		"Multiple   spaces\tand\ttabs   between    words.":                                                                 6,
+		"\n\nLines\nseparated\n\n  by\r\nnewlines\n\n":                                                                     4,
 	}
 
 	for sentence, expected := range scenarios {
@@ -59,6 +61,8 @@ func Test_toAbsoluteURI(t *testing.T) {
 		"www.google.com":         "http://localhost:8080/absolute/www.google.com",
 		"http//www.google.com":   "http://localhost:8080/absolute/http//www.google.com",
 		"../hello/relative":      "http://localhost:8080/hello/relative",
+		" test/123\n":            "http://localhost:8080/absolute/test/123",
+		"\t//www.google.com ":    "http://www.google.com",
 	}
 
 	for url, expected := range scenarios {
@@ -70,3 +74,119 @@ func Test_toAbsoluteURI(t *testing.T) {
 		}
 	}
 }
+
+func Test_isUnsafeURL(t *testing.T) {
+	scenarios := map[string]bool{
+		"javascript:alert(1)":               true,
+		" JavaScript:alert(1)":              true,
+		"java\tscript:alert(1)":             true,
+		"vbscript:msgbox(1)":                true,
+		"data:text/html,<script>x</script>": true,
+		"data:image/png;base64,iVBORw0KGgo": false,
+		"data:image/svg+xml,<svg onload=x>": true,
+		"DATA:IMAGE/SVG+XML;base64,PHN2Zz4": true,
+		"https://example.com/javascript:":   false,
+		"/relative/path":                    false,
+		"#here":                             false,
+	}
+
+	for url, expected := range scenarios {
+		if result := isUnsafeURL(url); result != expected {
+			t.Errorf("\n"+
+				"url  : %q\n"+
+				"want : %v\n"+
+				"got  : %v", url, expected, result)
+		}
+	}
+}
+
+func Test_isUnsafeEmbedURL(t *testing.T) {
+	scenarios := map[string]bool{
+		"javascript:alert(1)":                true,
+		"data:text/html,<script>x</script>":  true,
+		"data:image/svg+xml,<svg onload=x>":  true,
+		"data:image/png;base64,iVBORw0KGgo":  true,
+		" Data:image/png;base64,iVBORw0KGgo": true,
+		"https://www.youtube.com/embed/abc":  false,
+		"/relative/embed.html":               false,
+	}
+
+	for url, expected := range scenarios {
+		if result := isUnsafeEmbedURL(url); result != expected {
+			t.Errorf("\n"+
+				"url  : %q\n"+
+				"want : %v\n"+
+				"got  : %v", url, expected, result)
+		}
+	}
+}
+
+func Test_normalizeColor(t *testing.T) {
+	scenarios := map[string]string{
+		"#FFF":               "#ffffff",
+		"#1a2B3c":            "#1a2b3c",
+		"#1a2b3cff":          "#1a2b3c",
+		"rgb(255, 0, 128)":   "#ff0080",
+		"rgba(0,0,0,0.5)":    "#000000",
+		" Navy ":             "#000080",
+		"#12":                "",
+		"#ggg":               "",
+		"rgb(300, 0, 0)":     "",
+		"var(--brand-color)": "",
+	}
+
+	for color, expected := range scenarios {
+		if result := normalizeColor(color); result != expected {
+			t.Errorf("\n"+
+				"color : %q\n"+
+				"want  : %q\n"+
+				"got   : %q", color, expected, result)
+		}
+	}
+}
+
+func Test_repairMojibake(t *testing.T) {
+	scenarios := map[string]string{
+		"CafÃ© au lait": "Café au lait",
+		"Itâ€™s a â€œquotedâ€\u009d word": "It’s a “quoted” word",
+		"FranÃ§ois Truffaut":              "François Truffaut",
+		"Café au lait":                    "Café au lait",
+		"Ã©tÃ© in 日本":                     "Ã©tÃ© in 日本",
+		"plain ascii text":                "plain ascii text",
+	}
+
+	for str, expected := range scenarios {
+		if result := repairMojibake(str); result != expected {
+			t.Errorf("\n"+
+				"str  : \"%s\"\n"+
+				"want : \"%s\"\n"+
+				"got  : \"%s\"", str, expected, result)
+		}
+	}
+}
+
+func Test_truncateText(t *testing.T) {
+	scenarios := []struct {
+		str       string
+		maxLength int
+		want      string
+	}{
+		{"The quick brown fox jumps over the lazy dog.", 0, "The quick brown fox jumps over the lazy dog."},
+		{"The quick brown fox jumps over the lazy dog.", 100, "The quick brown fox jumps over the lazy dog."},
+		{"The quick brown fox jumps over the lazy dog.", 44, "The quick brown fox jumps over the lazy dog."},
+		{"The quick brown fox jumps over the lazy dog.", 18, "The quick brown…"},
+		{"The quick brown fox jumps over the lazy dog.", 19, "The quick brown…"},
+		{"The quick brown fox jumps over the lazy dog.", 20, "The quick brown fox…"},
+		{"The quick brown fox, jumps over the lazy dog.", 22, "The quick brown fox…"},
+		{"Ünïcödé wörds äre cöünted äs rünes.", 20, "Ünïcödé wörds äre…"},
+		{"敏捷的棕色狐狸跳过了懒狗", 5, "敏捷的棕…"},
+		{"Supercalifragilisticexpialidocious", 10, "Supercali…"},
+		{"Supercalifragilisticexpialidocious", 1, "…"},
+	}
+
+	for _, scenario := range scenarios {
+		if got := truncateText(scenario.str, scenario.maxLength); got != scenario.want {
+			t.Errorf("\n%q (%d): want %q, got %q", scenario.str, scenario.maxLength, scenario.want, got)
+		}
+	}
+}